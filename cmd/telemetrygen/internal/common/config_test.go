@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyValueSet(t *testing.T) {
+	tests := []struct {
+		flag     string
+		expected KeyValue
+		err      error
+	}{
+		{
+			flag:     "key=\"value\"",
+			expected: KeyValue(map[string]string{"key": "value"}),
+		},
+		{
+			flag:     "key=\"\"",
+			expected: KeyValue(map[string]string{"key": ""}),
+		},
+		{
+			flag: "key=\"",
+			err:  errDoubleQuotesOTLPAttributes,
+		},
+		{
+			flag: "key=value",
+			err:  errDoubleQuotesOTLPAttributes,
+		},
+		{
+			flag: "key",
+			err:  errFormatOTLPAttributes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			kv := KeyValue(make(map[string]string))
+			err := kv.Set(tt.flag)
+			if err != nil || tt.err != nil {
+				assert.Equal(t, err, tt.err)
+			} else {
+				assert.Equal(t, tt.expected, kv)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		endpoint string
+		httpURL  string
+	}{
+		{
+			name:     "default gRPC",
+			cfg:      Config{},
+			endpoint: "localhost:4317",
+			httpURL:  "https://localhost:4317/v1/metrics",
+		},
+		{
+			name:     "default HTTP",
+			cfg:      Config{UseHTTP: true, Insecure: true},
+			endpoint: "localhost:4318",
+			httpURL:  "http://localhost:4318/v1/metrics",
+		},
+		{
+			name:     "custom endpoint and path",
+			cfg:      Config{UseHTTP: true, CustomEndpoint: "collector:8080", HTTPPath: "/otlp/metrics"},
+			endpoint: "collector:8080",
+			httpURL:  "https://collector:8080/otlp/metrics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.endpoint, tt.cfg.Endpoint())
+			assert.Equal(t, tt.httpURL, tt.cfg.HTTPURL("/v1/metrics"))
+		})
+	}
+}
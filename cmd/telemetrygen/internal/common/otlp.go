@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const serviceNameKey = "service.name"
+
+// SetResourceAttributes sets the service name and the custom resource
+// attributes on res. Custom attributes may override the service name.
+func (c *Config) SetResourceAttributes(res pcommon.Resource) {
+	attrs := res.Attributes()
+	attrs.PutString(serviceNameKey, c.ServiceName)
+	for k, v := range c.ResourceAttributes {
+		attrs.PutString(k, v)
+	}
+}
+
+// DialGRPC opens a blocking gRPC connection to the configured endpoint.
+func (c *Config) DialGRPC(ctx context.Context) (*grpc.ClientConn, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if c.Insecure {
+		creds = insecure.NewCredentials()
+	}
+	return grpc.DialContext(ctx, c.Endpoint(), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+}
+
+// OutgoingContext attaches the custom headers to ctx as gRPC metadata.
+func (c *Config) OutgoingContext(ctx context.Context) context.Context {
+	if len(c.Headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.Headers))
+}
+
+// HTTPURL returns the URL requests are sent to when exporting over HTTP,
+// using defaultPath unless a custom path was configured.
+func (c *Config) HTTPURL(defaultPath string) string {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	path := defaultPath
+	if c.HTTPPath != "" {
+		path = c.HTTPPath
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, c.Endpoint(), path)
+}
+
+// PostProto sends an OTLP protobuf encoded request body to url, adding the
+// custom headers to the request.
+func (c *Config) PostProto(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("export to %s failed with status %s", url, resp.Status)
+	}
+	return nil
+}
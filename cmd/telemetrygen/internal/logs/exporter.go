@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+)
+
+const defaultHTTPPath = "/v1/logs"
+
+type exporter interface {
+	export(context.Context, plog.Logs) error
+}
+
+type grpcExporter struct {
+	cfg    *Config
+	client plogotlp.Client
+}
+
+func newGRPCExporter(cfg *Config, conn *grpc.ClientConn) exporter {
+	return &grpcExporter{cfg: cfg, client: plogotlp.NewClient(conn)}
+}
+
+func (e *grpcExporter) export(ctx context.Context, ld plog.Logs) error {
+	_, err := e.client.Export(e.cfg.OutgoingContext(ctx), plogotlp.NewRequestFromLogs(ld))
+	return err
+}
+
+type httpExporter struct {
+	cfg    *Config
+	client *http.Client
+	url    string
+}
+
+func newHTTPExporter(cfg *Config, client *http.Client) exporter {
+	return &httpExporter{cfg: cfg, client: client, url: cfg.HTTPURL(defaultHTTPPath)}
+}
+
+func (e *httpExporter) export(ctx context.Context, ld plog.Logs) error {
+	body, err := plogotlp.NewRequestFromLogs(ld).MarshalProto()
+	if err != nil {
+		return err
+	}
+	return e.cfg.PostProto(ctx, e.client, e.url, body)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []plog.LogRecord
+}
+
+func (m *mockExporter) export(_ context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				m.logs = append(m.logs, lrs.At(k))
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockExporter) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.logs)
+}
+
+func TestFixedNumberOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumLogs:        5,
+		Body:           "hello",
+		SeverityText:   "Warn",
+		SeverityNumber: 13,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	require.Equal(t, 5, exp.len())
+	lr := exp.logs[0]
+	assert.Equal(t, "hello", lr.Body().AsString())
+	assert.Equal(t, "Warn", lr.SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+	assert.NotZero(t, lr.Timestamp())
+}
+
+func TestRateOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, exp.len() >= 5, "there should have been 5 or more logs, had %d", exp.len())
+	// the maximum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, exp.len() <= 20, "there should have been less than 20 logs, had %d", exp.len())
+}
+
+func TestRequiresCountOrDuration(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+	}
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
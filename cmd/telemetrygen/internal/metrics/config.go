@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// MetricType is the type of metric that is generated.
+type MetricType string
+
+const (
+	metricTypeGauge                MetricType = "Gauge"
+	metricTypeSum                  MetricType = "Sum"
+	metricTypeHistogram            MetricType = "Histogram"
+	metricTypeExponentialHistogram MetricType = "ExponentialHistogram"
+	metricTypeSummary              MetricType = "Summary"
+)
+
+var _ pflag.Value = (*MetricType)(nil)
+
+func (t *MetricType) String() string {
+	return string(*t)
+}
+
+func (t *MetricType) Set(s string) error {
+	switch MetricType(s) {
+	case metricTypeGauge, metricTypeSum, metricTypeHistogram, metricTypeExponentialHistogram, metricTypeSummary:
+		*t = MetricType(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown metric type %q, must be one of: Gauge, Sum, Histogram, ExponentialHistogram, Summary", s)
+	}
+}
+
+func (t *MetricType) Type() string {
+	return "MetricType"
+}
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	NumMetrics int
+	MetricName string
+	MetricType MetricType
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of metrics to generate in each worker (ignored if duration is provided)")
+	fs.StringVar(&c.MetricName, "metric-name", "gen", "Name of the generated metric")
+
+	c.MetricType = metricTypeGauge
+	fs.Var(&c.MetricType, "metric-type", "Metric type enum. One of: Gauge, Sum, Histogram, ExponentialHistogram, Summary")
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"google.golang.org/grpc"
+)
+
+const defaultHTTPPath = "/v1/metrics"
+
+type exporter interface {
+	export(context.Context, pmetric.Metrics) error
+}
+
+type grpcExporter struct {
+	cfg    *Config
+	client pmetricotlp.Client
+}
+
+func newGRPCExporter(cfg *Config, conn *grpc.ClientConn) exporter {
+	return &grpcExporter{cfg: cfg, client: pmetricotlp.NewClient(conn)}
+}
+
+func (e *grpcExporter) export(ctx context.Context, md pmetric.Metrics) error {
+	_, err := e.client.Export(e.cfg.OutgoingContext(ctx), pmetricotlp.NewRequestFromMetrics(md))
+	return err
+}
+
+type httpExporter struct {
+	cfg    *Config
+	client *http.Client
+	url    string
+}
+
+func newHTTPExporter(cfg *Config, client *http.Client) exporter {
+	return &httpExporter{cfg: cfg, client: client, url: cfg.HTTPURL(defaultHTTPPath)}
+}
+
+func (e *httpExporter) export(ctx context.Context, md pmetric.Metrics) error {
+	body, err := pmetricotlp.NewRequestFromMetrics(md).MarshalProto()
+	if err != nil {
+		return err
+	}
+	return e.cfg.PostProto(ctx, e.client, e.url, body)
+}
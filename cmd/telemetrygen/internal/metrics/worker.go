@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+type worker struct {
+	cfg            *Config         // the scenario, used to build the resource and metric
+	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numMetrics     int             // how many metrics the worker has to generate (only when duration==0)
+	limitPerSecond rate.Limit      // how many metrics per second to generate
+	wg             *sync.WaitGroup // notify when done
+	index          int             // worker index, added as an attribute to keep the series of each worker apart
+	logger         *zap.Logger
+}
+
+const scopeName = "telemetrygen"
+
+// explicitBounds are the bucket boundaries of generated histograms.
+var explicitBounds = []float64{1, 2, 5, 10}
+
+func (w worker) simulateMetrics(exp exporter) {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	start := pcommon.NewTimestampFromTime(time.Now())
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter wait failed, retry", zap.Error(err))
+		}
+
+		i++
+		if err := exp.export(context.Background(), w.newMetrics(start, i)); err != nil {
+			w.logger.Error("exporter failed", zap.Error(err))
+		}
+
+		if w.numMetrics != 0 {
+			if i >= w.numMetrics {
+				break
+			}
+		}
+	}
+	w.logger.Info("metrics generated", zap.Int("metrics", i))
+	w.wg.Done()
+}
+
+// newMetrics builds a single data point of the configured metric type for
+// the i-th iteration. Sums count the iterations, all other types record a
+// value cycling between 1 and 10.
+func (w worker) newMetrics(start pcommon.Timestamp, i int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	w.cfg.SetResourceAttributes(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(w.cfg.MetricName)
+	now := pcommon.NewTimestampFromTime(time.Now())
+	value := float64(i%10 + 1)
+
+	var attrs pcommon.Map
+	switch w.cfg.MetricType {
+	case metricTypeSum:
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(now)
+		dp.SetIntVal(int64(i))
+		attrs = dp.Attributes()
+	case metricTypeHistogram:
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(now)
+		dp.SetTimestamp(now)
+		dp.SetCount(1)
+		dp.SetSum(value)
+		dp.SetMin(value)
+		dp.SetMax(value)
+		dp.ExplicitBounds().FromRaw(explicitBounds)
+		counts := make([]uint64, len(explicitBounds)+1)
+		counts[sort.SearchFloat64s(explicitBounds, value)] = 1
+		dp.BucketCounts().FromRaw(counts)
+		attrs = dp.Attributes()
+	case metricTypeExponentialHistogram:
+		hist := m.SetEmptyExponentialHistogram()
+		hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(now)
+		dp.SetTimestamp(now)
+		dp.SetCount(1)
+		dp.SetSum(value)
+		dp.SetMin(value)
+		dp.SetMax(value)
+		// At scale 0 the bucket with index i holds the values in (2^i, 2^(i+1)].
+		dp.SetScale(0)
+		dp.Positive().SetOffset(exponentialBucketIndex(value))
+		dp.Positive().BucketCounts().FromRaw([]uint64{1})
+		attrs = dp.Attributes()
+	case metricTypeSummary:
+		summary := m.SetEmptySummary()
+		dp := summary.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(now)
+		dp.SetTimestamp(now)
+		dp.SetCount(1)
+		dp.SetSum(value)
+		for _, q := range []float64{0, 0.5, 1} {
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(q)
+			qv.SetValue(value)
+		}
+		attrs = dp.Attributes()
+	default:
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetDoubleVal(value)
+		attrs = dp.Attributes()
+	}
+	attrs.PutInt("worker", int64(w.index))
+	return md
+}
+
+// exponentialBucketIndex returns the index of the scale 0 bucket holding
+// the positive value v.
+func exponentialBucketIndex(v float64) int32 {
+	frac, exp := math.Frexp(v)
+	if frac == 0.5 {
+		// Exact powers of two belong to the lower bucket.
+		exp--
+	}
+	return int32(exp - 1)
+}
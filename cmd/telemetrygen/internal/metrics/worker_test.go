@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []pmetric.Metric
+}
+
+func (m *mockExporter) export(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m.metrics = append(m.metrics, ms.At(k))
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockExporter) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.metrics)
+}
+
+func TestFixedNumberOfMetrics(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+		},
+		NumMetrics: 5,
+		MetricName: "gen",
+		MetricType: metricTypeSum,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	assert.Equal(t, 10, exp.len())
+}
+
+func TestRateOfMetrics(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		MetricType: metricTypeGauge,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, exp.len() >= 5, "there should have been 5 or more metrics, had %d", exp.len())
+	// the maximum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, exp.len() <= 20, "there should have been less than 20 metrics, had %d", exp.len())
+}
+
+func TestRequiresCountOrDuration(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+	}
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
+
+func TestMetricTypes(t *testing.T) {
+	tests := []struct {
+		metricType MetricType
+		verify     func(t *testing.T, m pmetric.Metric)
+	}{
+		{
+			metricType: metricTypeGauge,
+			verify: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+				dp := m.Gauge().DataPoints().At(0)
+				assert.Equal(t, 2.0, dp.DoubleVal())
+			},
+		},
+		{
+			metricType: metricTypeSum,
+			verify: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricDataTypeSum, m.DataType())
+				assert.True(t, m.Sum().IsMonotonic())
+				dp := m.Sum().DataPoints().At(0)
+				assert.Equal(t, int64(1), dp.IntVal())
+				assert.NotZero(t, dp.StartTimestamp())
+			},
+		},
+		{
+			metricType: metricTypeHistogram,
+			verify: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricDataTypeHistogram, m.DataType())
+				dp := m.Histogram().DataPoints().At(0)
+				assert.Equal(t, uint64(1), dp.Count())
+				assert.Equal(t, 2.0, dp.Sum())
+				assert.Equal(t, explicitBounds, dp.ExplicitBounds().AsRaw())
+				assert.Equal(t, []uint64{0, 1, 0, 0, 0}, dp.BucketCounts().AsRaw())
+			},
+		},
+		{
+			metricType: metricTypeExponentialHistogram,
+			verify: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricDataTypeExponentialHistogram, m.DataType())
+				dp := m.ExponentialHistogram().DataPoints().At(0)
+				assert.Equal(t, uint64(1), dp.Count())
+				assert.Equal(t, 2.0, dp.Sum())
+				assert.Equal(t, int32(0), dp.Positive().Offset())
+				assert.Equal(t, []uint64{1}, dp.Positive().BucketCounts().AsRaw())
+			},
+		},
+		{
+			metricType: metricTypeSummary,
+			verify: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricDataTypeSummary, m.DataType())
+				dp := m.Summary().DataPoints().At(0)
+				assert.Equal(t, uint64(1), dp.Count())
+				assert.Equal(t, 2.0, dp.Sum())
+				assert.Equal(t, 3, dp.QuantileValues().Len())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metricType), func(t *testing.T) {
+			exp := &mockExporter{}
+			cfg := &Config{
+				Config: common.Config{
+					WorkerCount: 1,
+				},
+				NumMetrics: 1,
+				MetricName: "gen",
+				MetricType: tt.metricType,
+			}
+			require.NoError(t, Run(cfg, exp, zap.NewNop()))
+			require.Equal(t, 1, exp.len())
+			assert.Equal(t, "gen", exp.metrics[0].Name())
+			tt.verify(t, exp.metrics[0])
+		})
+	}
+}
+
+func TestMetricTypeSet(t *testing.T) {
+	var mt MetricType
+	assert.NoError(t, mt.Set("ExponentialHistogram"))
+	assert.Equal(t, metricTypeExponentialHistogram, mt)
+	assert.Error(t, mt.Set("Counter"))
+	assert.Equal(t, metricTypeExponentialHistogram, mt)
+}
+
+func TestExponentialBucketIndex(t *testing.T) {
+	tests := []struct {
+		value float64
+		index int32
+	}{
+		{value: 0.75, index: -1},
+		{value: 1, index: -1},
+		{value: 1.5, index: 0},
+		{value: 2, index: 0},
+		{value: 3, index: 1},
+		{value: 4, index: 1},
+		{value: 10, index: 3},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.index, exponentialBucketIndex(tt.value), "value %v", tt.value)
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	received := make(chan pmetric.Metrics, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := pmetricotlp.NewRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received <- req.Metrics()
+	}))
+	defer srv.Close()
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:        1,
+			ServiceName:        "telemetrygen",
+			CustomEndpoint:     srv.Listener.Addr().String(),
+			Insecure:           true,
+			UseHTTP:            true,
+			Headers:            common.KeyValue{"Authorization": "secret"},
+			ResourceAttributes: common.KeyValue{"k8s.pod.name": "pod"},
+		},
+		NumMetrics: 1,
+		MetricName: "gen",
+		MetricType: metricTypeGauge,
+	}
+	require.NoError(t, Run(cfg, newHTTPExporter(cfg, srv.Client()), zap.NewNop()))
+
+	md := <-received
+	require.Equal(t, 1, md.DataPointCount())
+	assert.Equal(t, map[string]interface{}{
+		"service.name": "telemetrygen",
+		"k8s.pod.name": "pod",
+	}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+}
@@ -26,6 +26,8 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricRouting
 )
 
 // Config defines configuration for the exporter.
@@ -45,6 +47,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -59,3 +62,11 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sSvcResolver defines the configuration for the Kubernetes resolver, watching the endpoints of a service
+type K8sSvcResolver struct {
+	// Service is the name of the service, optionally followed by its namespace: "name.namespace"
+	Service string `mapstructure:"service"`
+	// Ports are the ports to export to on each endpoint, each one being a backend of its own
+	Ports []int32 `mapstructure:"ports"`
+}
@@ -28,6 +28,8 @@ const (
 	typeStr = "loadbalancing"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// The stability level of the metrics exporter.
+	metricsStability = component.StabilityLevelInDevelopment
 )
 
 // NewFactory creates a factory for the exporter.
@@ -39,6 +41,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		component.WithTracesExporter(createTracesExporter, stability),
 		component.WithLogsExporter(createLogsExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, metricsStability),
 	)
 }
 
@@ -61,3 +64,7 @@ func createTracesExporter(_ context.Context, params component.ExporterCreateSett
 func createLogsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	return newMetricsExporter(params, cfg)
+}
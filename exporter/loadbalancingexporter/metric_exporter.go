@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+const routingKeySeparator = string(byte(0))
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: svcRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service", "":
+	case "resource":
+		metricExporter.routingKey = resourceRouting
+	case "metric":
+		metricExporter.routingKey = metricRouting
+	default:
+		return nil, fmt.Errorf("unsupported routing_key for metrics: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batches, err := splitMetricsByEndpoint(md, e.routingKey, e.loadBalancer.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batch.md))
+	}
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// metricBatch holds the metrics routed to one endpoint. The resource, scope and
+// metric being split are copied into it along with the first data point routed to it.
+type metricBatch struct {
+	md pmetric.Metrics
+
+	rm                 pmetric.ResourceMetrics
+	sm                 pmetric.ScopeMetrics
+	m                  pmetric.Metric
+	rmIdx, smIdx, mIdx int
+}
+
+// splitMetricsByEndpoint returns the metrics to send to each endpoint. With the
+// service and resource routing keys whole resources are routed, while with the
+// metric routing key each data point is routed on its own.
+func splitMetricsByEndpoint(md pmetric.Metrics, key routingKey, endpointFor func([]byte) string) (map[string]*metricBatch, error) {
+	batches := make(map[string]*metricBatch)
+	batchFor := func(routingID string) *metricBatch {
+		endpoint := endpointFor([]byte(routingID))
+		b, ok := batches[endpoint]
+		if !ok {
+			b = &metricBatch{md: pmetric.NewMetrics(), rmIdx: -1, smIdx: -1, mIdx: -1}
+			batches[endpoint] = b
+		}
+		return b
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		switch key {
+		case svcRouting:
+			svc, ok := rm.Resource().Attributes().Get("service.name")
+			if !ok {
+				return nil, errors.New("unable to get service name")
+			}
+			rm.CopyTo(batchFor(svc.StringVal()).md.ResourceMetrics().AppendEmpty())
+		case resourceRouting:
+			rm.CopyTo(batchFor(attributesRoutingID(rm.Resource().Attributes())).md.ResourceMetrics().AppendEmpty())
+		case metricRouting:
+			resourceID := attributesRoutingID(rm.Resource().Attributes())
+			sms := rm.ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				sm := sms.At(j)
+				ms := sm.Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					m := ms.At(k)
+					metricID := resourceID + routingKeySeparator + m.Name() + routingKeySeparator
+					metricFor := func(attrs pcommon.Map) pmetric.Metric {
+						return batchFor(metricID+attributesRoutingID(attrs)).metric(rm, i, sm, j, m, k)
+					}
+					splitDataPoints(m, metricFor)
+				}
+			}
+		}
+	}
+	return batches, nil
+}
+
+// splitDataPoints copies each data point of the metric into the metric returned
+// for its attributes.
+func splitDataPoints(m pmetric.Metric, metricFor func(pcommon.Map) pmetric.Metric) {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(metricFor(dps.At(l).Attributes()).Gauge().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(metricFor(dps.At(l).Attributes()).Sum().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(metricFor(dps.At(l).Attributes()).Histogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(metricFor(dps.At(l).Attributes()).ExponentialHistogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(metricFor(dps.At(l).Attributes()).Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+// metric returns the copy of the k-th metric of the j-th scope of the i-th
+// resource in the batch, without data points when it was just created.
+func (b *metricBatch) metric(rm pmetric.ResourceMetrics, i int, sm pmetric.ScopeMetrics, j int, m pmetric.Metric, k int) pmetric.Metric {
+	if b.rmIdx != i {
+		b.rm = b.md.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(b.rm.Resource())
+		b.rm.SetSchemaUrl(rm.SchemaUrl())
+		b.rmIdx, b.smIdx, b.mIdx = i, -1, -1
+	}
+	if b.smIdx != j {
+		b.sm = b.rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(b.sm.Scope())
+		b.sm.SetSchemaUrl(sm.SchemaUrl())
+		b.smIdx, b.mIdx = j, -1
+	}
+	if b.mIdx != k {
+		b.m = b.sm.Metrics().AppendEmpty()
+		copyMetricDescription(m, b.m)
+		b.mIdx = k
+	}
+	return b.m
+}
+
+// copyMetricDescription copies everything but the data points of the metric.
+func copyMetricDescription(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	switch src.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricDataTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricDataTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
+// attributesRoutingID builds an identifier of the attributes which doesn't
+// depend on their order.
+func attributesRoutingID(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+routingKeySeparator+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, routingKeySeparator)
+}
@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			},
+			errNoResolver,
+		},
+		{
+			"unsupported routing key",
+			func() *Config {
+				cfg := simpleConfig()
+				cfg.RoutingKey = "traceID"
+				return cfg
+			}(),
+			errors.New("unsupported routing_key for metrics: traceID"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		expected   routingKey
+	}{
+		{"", svcRouting},
+		{"service", svcRouting},
+		{"resource", resourceRouting},
+		{"metric", metricRouting},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.RoutingKey = tt.routingKey
+
+			// test
+			p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.routingKey)
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newMockMetricsExporter(sink.ConsumeMetrics), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+	// verify
+	assert.Nil(t, res)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].ResourceMetrics().Len())
+}
+
+func TestConsumeMetricsWithoutServiceName(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.EqualError(t, res, "unable to get service name")
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestSplitMetricsByEndpoint(t *testing.T) {
+	// every routing identifier gets its own endpoint
+	endpointFor := func(id []byte) string { return string(id) }
+
+	for _, tt := range []struct {
+		desc       string
+		routingKey routingKey
+		batches    int
+		dataPoints []int
+	}{
+		{"service", svcRouting, 2, []int{3, 1}},
+		{"resource", resourceRouting, 2, []int{3, 1}},
+		{"metric", metricRouting, 3, []int{2, 1, 1}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			md := twoServicesMetrics()
+
+			// test
+			batches, err := splitMetricsByEndpoint(md, tt.routingKey, endpointFor)
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, batches, tt.batches)
+			var dataPoints []int
+			for _, batch := range batches {
+				dataPoints = append(dataPoints, batch.md.DataPointCount())
+			}
+			assert.ElementsMatch(t, tt.dataPoints, dataPoints)
+		})
+	}
+}
+
+func TestSplitMetricsByEndpointKeepsDescription(t *testing.T) {
+	md := twoServicesMetrics()
+
+	// test
+	batches, err := splitMetricsByEndpoint(md, metricRouting, func(id []byte) string { return string(id) })
+
+	// verify
+	require.NoError(t, err)
+	for _, batch := range batches {
+		require.Equal(t, 1, batch.md.ResourceMetrics().Len())
+		rm := batch.md.ResourceMetrics().At(0)
+		svc, ok := rm.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		require.Equal(t, 1, rm.ScopeMetrics().Len())
+		assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+		require.Equal(t, 1, rm.ScopeMetrics().At(0).Metrics().Len())
+		m := rm.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "requests", m.Name())
+		assert.Equal(t, "1", m.Unit())
+		assert.True(t, m.Sum().IsMonotonic())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+
+		// the data points of the same series are kept together
+		dps := m.Sum().DataPoints()
+		for l := 1; l < dps.Len(); l++ {
+			assert.Equal(t, dps.At(0).Attributes().AsRaw(), dps.At(l).Attributes().AsRaw())
+		}
+		if svc.StringVal() == "service-2" {
+			assert.Equal(t, 1, dps.Len())
+		}
+	}
+}
+
+func TestAttributesRoutingIDIgnoresOrder(t *testing.T) {
+	md := pmetric.NewMetrics()
+	first := md.ResourceMetrics().AppendEmpty().Resource().Attributes()
+	first.PutString("a", "1")
+	first.PutString("b", "2")
+	second := md.ResourceMetrics().AppendEmpty().Resource().Attributes()
+	second.PutString("b", "2")
+	second.PutString("a", "1")
+	third := md.ResourceMetrics().AppendEmpty().Resource().Attributes()
+	third.PutString("a", "2")
+	third.PutString("b", "1")
+
+	assert.Equal(t, attributesRoutingID(first), attributesRoutingID(second))
+	assert.NotEqual(t, attributesRoutingID(first), attributesRoutingID(third))
+}
+
+// twoServicesMetrics returns a monotonic sum for two services. The first one
+// has three data points, in two series.
+func twoServicesMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, svc := range []struct {
+		name   string
+		routes []string
+	}{
+		{"service-1", []string{"/a", "/b", "/a"}},
+		{"service-2", []string{"/a"}},
+	} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		fillResource(rm.Resource(), svc.name)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("scope")
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetUnit("1")
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		for i, route := range svc.routes {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutString("route", route)
+			dp.SetIntVal(int64(i))
+		}
+	}
+	return md
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewK8sResolver(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		service   string
+		ports     []int32
+		name      string
+		namespace string
+		wantPorts []int32
+		err       error
+	}{
+		{
+			desc:      "service with namespace",
+			service:   "lb.monitoring",
+			ports:     []int32{8080, 9090},
+			name:      "lb",
+			namespace: "monitoring",
+			wantPorts: []int32{8080, 9090},
+		},
+		{
+			desc:      "service without namespace nor ports",
+			service:   "lb",
+			name:      "lb",
+			namespace: "default",
+			wantPorts: []int32{4317},
+		},
+		{
+			desc: "no service",
+			err:  errNoSvc,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), tt.service, tt.ports)
+
+			// verify
+			require.Equal(t, tt.err, err)
+			if tt.err != nil {
+				return
+			}
+			assert.Equal(t, tt.name, res.svcName)
+			assert.Equal(t, tt.namespace, res.svcNs)
+			assert.Equal(t, tt.wantPorts, res.ports)
+		})
+	}
+}
+
+func TestK8sResolve(t *testing.T) {
+	// prepare
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "monitoring"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{
+				{IP: "192.168.10.100"},
+				{IP: "2001:db8::1"},
+			},
+			NotReadyAddresses: []corev1.EndpointAddress{
+				{IP: "192.168.10.101"},
+			},
+		}},
+	}
+	otherEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "monitoring"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "192.168.20.100"}},
+		}},
+	}
+	clt := fake.NewSimpleClientset(endpoints, otherEndpoints)
+
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.monitoring", []int32{4317, 8080})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	lastResolved := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{
+		"192.168.10.100:4317",
+		"192.168.10.100:8080",
+		"[2001:db8::1]:4317",
+		"[2001:db8::1]:8080",
+	}, lastResolved())
+
+	// a pod is added
+	updated := endpoints.DeepCopy()
+	updated.Subsets[0].Addresses = []corev1.EndpointAddress{{IP: "192.168.10.100"}, {IP: "192.168.10.102"}}
+	_, err = clt.CoreV1().Endpoints("monitoring").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"192.168.10.100:4317",
+			"192.168.10.100:8080",
+			"192.168.10.102:4317",
+			"192.168.10.102:8080",
+		}, lastResolved())
+	}, time.Second, 10*time.Millisecond)
+
+	// the service is removed
+	err = clt.CoreV1().Endpoints("monitoring").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(lastResolved()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolveWithoutEndpoints(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb.monitoring", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	// The Parquet library only ships with snappy and gzip support.
+	goparquet.RegisterBlockCompressor(parquet.CompressionCodec_ZSTD, newZstdCompressor())
+}
+
+// zstdCompressor implements goparquet.BlockCompressor. The encoder and
+// decoder are safe for concurrent use when only EncodeAll and DecodeAll are
+// called.
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() *zstdCompressor {
+	// Creating an encoder or decoder without options and a nil reader or
+	// writer does not fail.
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+	return &zstdCompressor{encoder: encoder, decoder: decoder}
+}
+
+func (c *zstdCompressor) CompressBlock(block []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(block, nil), nil
+}
+
+func (c *zstdCompressor) DecompressBlock(block []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(block, nil)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Compression codecs supported by the exporter.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionGzip   = "gzip"
+	CompressionZstd   = "zstd"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the directory the Parquet files are written to. Every signal
+	// writes its own set of files, prefixed with the signal name and the ID
+	// of the exporter.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used to compress column chunks. One of
+	// "none", "snappy", "gzip" or "zstd". Defaults to "snappy".
+	Compression string `mapstructure:"compression"`
+
+	// RowGroupMegabytes is the approximate size in megabytes a row group
+	// is allowed to grow to before it is flushed to the file.
+	RowGroupMegabytes int `mapstructure:"row_group_megabytes"`
+
+	// Rotation defines when the current file is closed and a new one started.
+	Rotation Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines the options for rolling over Parquet files.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of a file before it gets
+	// rotated. Zero disables size based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxAge is the maximum amount of time a file is kept open before it gets
+	// rotated. Zero disables time based rotation.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// LocalTime determines if the time used for formatting the timestamps in
+	// file names is the computer's local time. The default is to use UTC time.
+	LocalTime bool `mapstructure:"localtime"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	switch cfg.Compression {
+	case CompressionNone, CompressionSnappy, CompressionGzip, CompressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression %q", cfg.Compression)
+	}
+	if cfg.RowGroupMegabytes <= 0 {
+		return errors.New("row_group_megabytes must be positive")
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation.max_megabytes must not be negative")
+	}
+	if cfg.Rotation.MaxAge < 0 {
+		return errors.New("rotation.max_age must not be negative")
+	}
+	return nil
+}
@@ -16,33 +16,142 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
+// signal describes the files written for one telemetry signal.
+type signal struct {
+	// prefix is the file name prefix of the files written for the signal.
+	prefix string
+	schema string
+}
+
+var (
+	tracesSignal  = signal{prefix: "traces", schema: spansSchema}
+	metricsSignal = signal{prefix: "metrics", schema: dataPointsSchema}
+	logsSignal    = signal{prefix: "logs", schema: logsSchema}
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	CompressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	CompressionSnappy: parquet.CompressionCodec_SNAPPY,
+	CompressionGzip:   parquet.CompressionCodec_GZIP,
+	CompressionZstd:   parquet.CompressionCodec_ZSTD,
+}
+
+// parquetExporter writes the telemetry of a single signal to rolling
+// Parquet files.
 type parquetExporter struct {
-	path string
+	cfg    *Config
+	signal signal
+	logger *zap.Logger
+
+	writer *rollingWriter
+	done   chan struct{}
+	wg     sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, sig signal, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{
+		cfg:    cfg,
+		signal: sig,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
+	schemaDef, err := parquetschema.ParseSchemaDefinition(e.signal.schema)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s schema: %w", e.signal.prefix, err)
+	}
+	if err = os.MkdirAll(e.cfg.Path, 0700); err != nil {
+		return err
+	}
+	// The ID of the exporter is part of the file names, so that several
+	// exporters can write to the same path.
+	prefix := e.signal.prefix + "-" + strings.ReplaceAll(e.cfg.ID().String(), "/", "_")
+	removed, err := removeInProgressFiles(e.cfg.Path, prefix)
+	for _, name := range removed {
+		e.logger.Warn("Removed Parquet file left in progress", zap.String("file", name))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove %s files left in progress: %w", e.signal.prefix, err)
+	}
+
+	e.writer = newRollingWriter(e.cfg.Path, prefix, int64(e.cfg.RowGroupMegabytes)*1024*1024, e.cfg.Rotation,
+		goparquet.WithSchemaDefinition(schemaDef),
+		goparquet.WithCompressionCodec(compressionCodecs[e.cfg.Compression]),
+		goparquet.WithCreator("opentelemetry-collector-contrib parquetexporter"),
+	)
+
+	if e.cfg.Rotation.MaxAge > 0 {
+		e.wg.Add(1)
+		go e.rotateOnAge()
+	}
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+// rotateOnAge periodically closes files that have exceeded the maximum age
+// so that they become available to readers even if no new data arrives.
+func (e *parquetExporter) rotateOnAge() {
+	defer e.wg.Done()
+
+	// Check often enough that files are not kept open much longer than MaxAge.
+	interval := e.cfg.Rotation.MaxAge / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.writer.rotateIfExpired(); err != nil {
+				e.logger.Error("Failed to rotate Parquet file", zap.String("signal", e.signal.prefix), zap.Error(err))
+			}
+		case <-e.done:
+			return
+		}
+	}
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) shutdown(context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	close(e.done)
+	e.wg.Wait()
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return e.write(dataPointRecords(md))
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.write(spanRecords(td))
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.write(logRecords(ld))
+}
+
+func (e *parquetExporter) write(records []interfaces.Marshaller) error {
+	return e.writer.write(records)
 }
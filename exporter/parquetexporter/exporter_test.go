@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newTestExporter(t *testing.T, sig signal, modify func(cfg *Config)) *parquetExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	if modify != nil {
+		modify(cfg)
+	}
+	exp := newParquetExporter(cfg, sig, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func readRows(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := goparquet.NewFileReader(f)
+	require.NoError(t, err)
+
+	var rows []map[string]interface{}
+	for {
+		row, err := r.NextRow()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func parquetFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+	return files
+}
+
+func attributesFromRow(row map[string]interface{}, field string) map[string]string {
+	attrs := map[string]string{}
+	m, ok := row[field].(map[string]interface{})
+	if !ok {
+		return attrs
+	}
+	for _, kv := range m["key_value"].([]map[string]interface{}) {
+		attrs[string(kv["key"].([]byte))] = string(kv["value"].([]byte))
+	}
+	return attrs
+}
+
+func TestConsumeTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(1500))
+	span.Attributes().PutInt("http.status_code", 500)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.Timestamp(1200))
+	event.Attributes().PutString("exception.type", "NullPointerException")
+	span.Status().SetCode(ptrace.StatusCodeError)
+
+	exp := newTestExporter(t, tracesSignal, nil)
+	require.NoError(t, exp.consumeTraces(context.Background(), td))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := parquetFiles(t, exp.cfg.Path)
+	require.Len(t, files, 1)
+	assert.Regexp(t, `traces-.*\.parquet$`, files[0])
+
+	rows := readRows(t, files[0])
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, attributesFromRow(row, "resource_attributes"))
+	assert.Equal(t, []byte("tracer"), row["scope_name"])
+	assert.Equal(t, []byte("0102030405060708090a0b0c0d0e0f10"), row["trace_id"])
+	assert.Equal(t, []byte("0102030405060708"), row["span_id"])
+	assert.NotContains(t, row, "parent_span_id")
+	assert.Equal(t, []byte("GET /cart"), row["name"])
+	assert.Equal(t, []byte("SPAN_KIND_SERVER"), row["kind"])
+	assert.Equal(t, int64(500), row["duration_nano"])
+	assert.Equal(t, map[string]string{"http.status_code": "500"}, attributesFromRow(row, "attributes"))
+	assert.Equal(t, []byte("STATUS_CODE_ERROR"), row["status_code"])
+
+	events := row["events"].(map[string]interface{})["list"].([]map[string]interface{})
+	require.Len(t, events, 1)
+	ev := events[0]["element"].(map[string]interface{})
+	assert.Equal(t, []byte("exception"), ev["name"])
+	assert.Equal(t, int64(1200), ev["time_unix_nano"])
+	assert.Equal(t, map[string]string{"exception.type": "NullPointerException"}, attributesFromRow(ev, "attributes"))
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutString("service.name", "checkout")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetEmptyGauge()
+	dp := gauge.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(42)
+	dp.SetTimestamp(pcommon.Timestamp(100))
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum()
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetDoubleVal(3.5)
+	dp.Attributes().PutString("route", "/cart")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram()
+	histogram.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 0})
+	hdp.ExplicitBounds().FromRaw([]float64{5, 10})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("latency.exp")
+	expHistogram.SetEmptyExponentialHistogram()
+	edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetCount(4)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("latency.summary")
+	summary.SetEmptySummary()
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetCount(10)
+	sdp.SetSum(100)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(42)
+
+	exp := newTestExporter(t, metricsSignal, func(cfg *Config) { cfg.Compression = CompressionZstd })
+	require.NoError(t, exp.consumeMetrics(context.Background(), md))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := parquetFiles(t, exp.cfg.Path)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0])
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, []byte("queue.size"), rows[0]["metric_name"])
+	assert.Equal(t, []byte("Gauge"), rows[0]["metric_type"])
+	assert.Equal(t, int64(42), rows[0]["value_int"])
+	assert.NotContains(t, rows[0], "value_double")
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, attributesFromRow(rows[0], "resource_attributes"))
+
+	assert.Equal(t, []byte("Sum"), rows[1]["metric_type"])
+	assert.Equal(t, 3.5, rows[1]["value_double"])
+	assert.Equal(t, true, rows[1]["is_monotonic"])
+	assert.Equal(t, []byte("AGGREGATION_TEMPORALITY_CUMULATIVE"), rows[1]["aggregation_temporality"])
+	assert.Equal(t, map[string]string{"route": "/cart"}, attributesFromRow(rows[1], "attributes"))
+
+	assert.Equal(t, []byte("Histogram"), rows[2]["metric_type"])
+	assert.Equal(t, int64(3), rows[2]["count"])
+	assert.Equal(t, float64(12), rows[2]["sum"])
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(0)}, listFromRow(rows[2], "bucket_counts"))
+	assert.Equal(t, []interface{}{float64(5), float64(10)}, listFromRow(rows[2], "explicit_bounds"))
+
+	assert.Equal(t, []byte("ExponentialHistogram"), rows[3]["metric_type"])
+	assert.Equal(t, int32(2), rows[3]["scale"])
+	assert.Equal(t, int64(1), rows[3]["zero_count"])
+	assert.Equal(t, int32(-1), rows[3]["positive_offset"])
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, listFromRow(rows[3], "positive_bucket_counts"))
+	assert.NotContains(t, rows[3], "negative_bucket_counts")
+
+	assert.Equal(t, []byte("Summary"), rows[4]["metric_type"])
+	quantiles := listFromRow(rows[4], "quantile_values")
+	require.Len(t, quantiles, 1)
+	assert.Equal(t, map[string]interface{}{"quantile": 0.99, "value": float64(42)}, quantiles[0])
+}
+
+func listFromRow(row map[string]interface{}, field string) []interface{} {
+	var values []interface{}
+	for _, elem := range row[field].(map[string]interface{})["list"].([]map[string]interface{}) {
+		values = append(values, elem["element"])
+	}
+	return values
+}
+
+func TestConsumeLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutString("host.name", "web-1")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(10))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStringVal("something failed")
+	lr.Attributes().PutBool("retry", true)
+
+	exp := newTestExporter(t, logsSignal, func(cfg *Config) { cfg.Compression = CompressionGzip })
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files := parquetFiles(t, exp.cfg.Path)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0])
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, int64(10), row["time_unix_nano"])
+	assert.NotContains(t, row, "observed_time_unix_nano")
+	assert.NotContains(t, row, "trace_id")
+	assert.Equal(t, int32(plog.SeverityNumberError), row["severity_number"])
+	assert.Equal(t, []byte("ERROR"), row["severity_text"])
+	assert.Equal(t, []byte("something failed"), row["body"])
+	assert.Equal(t, map[string]string{"retry": "true"}, attributesFromRow(row, "attributes"))
+	assert.Equal(t, map[string]string{"host.name": "web-1"}, attributesFromRow(row, "resource_attributes"))
+}
+
+func TestNoFileWithoutData(t *testing.T) {
+	exp := newTestExporter(t, logsSignal, nil)
+	require.NoError(t, exp.consumeLogs(context.Background(), plog.NewLogs()))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	entries, err := os.ReadDir(exp.cfg.Path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRotateOnAge(t *testing.T) {
+	exp := newTestExporter(t, logsSignal, func(cfg *Config) { cfg.Rotation.MaxAge = time.Second })
+	defer func() { require.NoError(t, exp.shutdown(context.Background())) }()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStringVal("hello")
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+
+	// The file only becomes visible once it is rotated by the background loop.
+	assert.Empty(t, parquetFiles(t, exp.cfg.Path))
+	assert.Eventually(t, func() bool {
+		return len(parquetFiles(t, exp.cfg.Path)) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestStartRemovesInProgressFiles(t *testing.T) {
+	dir := t.TempDir()
+	leftover := filepath.Join(dir, "logs-parquet-2022-10-01T12-00-00.000000000.parquet.inprogress")
+	require.NoError(t, os.WriteFile(leftover, []byte("PAR1"), 0600))
+	// The file of another exporter writing to the same path is left alone
+	other := filepath.Join(dir, "logs-parquet_other-2022-10-01T12-00-00.000000000.parquet.inprogress")
+	require.NoError(t, os.WriteFile(other, []byte("PAR1"), 0600))
+
+	exp := newTestExporter(t, logsSignal, func(cfg *Config) { cfg.Path = dir })
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	_, err := os.Stat(leftover)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(other)
+	assert.NoError(t, err)
+}
@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,12 +28,11 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelInDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultRowGroupMegabytes = 64
+	defaultMaxMegabytes      = 256
+	defaultMaxAge            = time.Hour
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
@@ -46,7 +46,13 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		ExporterSettings:  config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Compression:       CompressionSnappy,
+		RowGroupMegabytes: defaultRowGroupMegabytes,
+		Rotation: Rotation{
+			MaxMegabytes: defaultMaxMegabytes,
+			MaxAge:       defaultMaxAge,
+		},
 	}
 }
 
@@ -55,7 +61,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), tracesSignal, set.Logger)
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -71,7 +77,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), metricsSignal, set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -87,7 +93,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), logsSignal, set.Logger)
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logRecord is a single row of the logs schema.
+type logRecord struct {
+	resourceScope
+	record plog.LogRecord
+}
+
+func logRecords(ld plog.Logs) []interfaces.Marshaller {
+	records := make([]interfaces.Marshaller, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		slls := rl.ScopeLogs()
+		for j := 0; j < slls.Len(); j++ {
+			sl := slls.At(j)
+			scope := resourceScope{
+				resource:          rl.Resource(),
+				resourceSchemaURL: rl.SchemaUrl(),
+				scope:             sl.Scope(),
+				scopeSchemaURL:    sl.SchemaUrl(),
+			}
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				records = append(records, &logRecord{resourceScope: scope, record: lrs.At(k)})
+			}
+		}
+	}
+	return records
+}
+
+func (r *logRecord) MarshalParquet(obj interfaces.MarshalObject) error {
+	r.resourceScope.marshal(obj)
+
+	lr := r.record
+	setTimestamp(obj, "time_unix_nano", lr.Timestamp())
+	setTimestamp(obj, "observed_time_unix_nano", lr.ObservedTimestamp())
+	if !lr.TraceID().IsEmpty() {
+		obj.AddField("trace_id").SetByteArray([]byte(lr.TraceID().HexString()))
+	}
+	if !lr.SpanID().IsEmpty() {
+		obj.AddField("span_id").SetByteArray([]byte(lr.SpanID().HexString()))
+	}
+	obj.AddField("flags").SetInt64(int64(lr.Flags()))
+	obj.AddField("severity_number").SetInt32(int32(lr.SeverityNumber()))
+	setString(obj, "severity_text", lr.SeverityText())
+	setString(obj, "body", lr.Body().AsString())
+	setAttributes(obj, "attributes", lr.Attributes())
+	obj.AddField("dropped_attributes_count").SetInt64(int64(lr.DroppedAttributesCount()))
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// dataPointRecord is a single row of the data points schema. Exactly one
+// of the data point fields is set, depending on the metric type.
+type dataPointRecord struct {
+	resourceScope
+	metric pmetric.Metric
+
+	number       pmetric.NumberDataPoint
+	histogram    pmetric.HistogramDataPoint
+	expHistogram pmetric.ExponentialHistogramDataPoint
+	summary      pmetric.SummaryDataPoint
+}
+
+func dataPointRecords(md pmetric.Metrics) []interfaces.Marshaller {
+	records := make([]interfaces.Marshaller, 0, md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			scope := resourceScope{
+				resource:          rm.Resource(),
+				resourceSchemaURL: rm.SchemaUrl(),
+				scope:             ilm.Scope(),
+				scopeSchemaURL:    ilm.SchemaUrl(),
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				records = appendDataPointRecords(records, scope, metrics.At(k))
+			}
+		}
+	}
+	return records
+}
+
+func appendDataPointRecords(records []interfaces.Marshaller, scope resourceScope, metric pmetric.Metric) []interfaces.Marshaller {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			records = append(records, &dataPointRecord{resourceScope: scope, metric: metric, number: dps.At(i)})
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			records = append(records, &dataPointRecord{resourceScope: scope, metric: metric, number: dps.At(i)})
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			records = append(records, &dataPointRecord{resourceScope: scope, metric: metric, histogram: dps.At(i)})
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			records = append(records, &dataPointRecord{resourceScope: scope, metric: metric, expHistogram: dps.At(i)})
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			records = append(records, &dataPointRecord{resourceScope: scope, metric: metric, summary: dps.At(i)})
+		}
+	}
+	return records
+}
+
+func (r *dataPointRecord) MarshalParquet(obj interfaces.MarshalObject) error {
+	r.resourceScope.marshal(obj)
+
+	metric := r.metric
+	obj.AddField("metric_name").SetByteArray([]byte(metric.Name()))
+	setString(obj, "metric_description", metric.Description())
+	setString(obj, "metric_unit", metric.Unit())
+	obj.AddField("metric_type").SetByteArray([]byte(metric.DataType().String()))
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		marshalNumberDataPoint(obj, r.number)
+	case pmetric.MetricDataTypeSum:
+		setTemporality(obj, metric.Sum().AggregationTemporality())
+		obj.AddField("is_monotonic").SetBool(metric.Sum().IsMonotonic())
+		marshalNumberDataPoint(obj, r.number)
+	case pmetric.MetricDataTypeHistogram:
+		setTemporality(obj, metric.Histogram().AggregationTemporality())
+		marshalHistogramDataPoint(obj, r.histogram)
+	case pmetric.MetricDataTypeExponentialHistogram:
+		setTemporality(obj, metric.ExponentialHistogram().AggregationTemporality())
+		marshalExponentialHistogramDataPoint(obj, r.expHistogram)
+	case pmetric.MetricDataTypeSummary:
+		marshalSummaryDataPoint(obj, r.summary)
+	}
+	return nil
+}
+
+func setTemporality(obj interfaces.MarshalObject, temporality pmetric.MetricAggregationTemporality) {
+	obj.AddField("aggregation_temporality").SetByteArray([]byte(temporality.String()))
+}
+
+func setCommonDataPointFields(obj interfaces.MarshalObject, attrs pcommon.Map, start, ts pcommon.Timestamp, flags pmetric.MetricDataPointFlags) {
+	setAttributes(obj, "attributes", attrs)
+	setTimestamp(obj, "start_time_unix_nano", start)
+	obj.AddField("time_unix_nano").SetInt64(int64(ts))
+	obj.AddField("flags").SetInt64(int64(flags))
+}
+
+func marshalNumberDataPoint(obj interfaces.MarshalObject, dp pmetric.NumberDataPoint) {
+	setCommonDataPointFields(obj, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		obj.AddField("value_double").SetFloat64(dp.DoubleVal())
+	case pmetric.NumberDataPointValueTypeInt:
+		obj.AddField("value_int").SetInt64(dp.IntVal())
+	}
+}
+
+func marshalHistogramDataPoint(obj interfaces.MarshalObject, dp pmetric.HistogramDataPoint) {
+	setCommonDataPointFields(obj, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+	obj.AddField("count").SetInt64(int64(dp.Count()))
+	if dp.HasSum() {
+		obj.AddField("sum").SetFloat64(dp.Sum())
+	}
+	if dp.HasMin() {
+		obj.AddField("min").SetFloat64(dp.Min())
+	}
+	if dp.HasMax() {
+		obj.AddField("max").SetFloat64(dp.Max())
+	}
+	setUInt64List(obj, "bucket_counts", dp.BucketCounts())
+	if dp.ExplicitBounds().Len() > 0 {
+		list := obj.AddField("explicit_bounds").List()
+		for i := 0; i < dp.ExplicitBounds().Len(); i++ {
+			list.Add().SetFloat64(dp.ExplicitBounds().At(i))
+		}
+	}
+}
+
+func marshalExponentialHistogramDataPoint(obj interfaces.MarshalObject, dp pmetric.ExponentialHistogramDataPoint) {
+	setCommonDataPointFields(obj, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+	obj.AddField("count").SetInt64(int64(dp.Count()))
+	if dp.HasSum() {
+		obj.AddField("sum").SetFloat64(dp.Sum())
+	}
+	if dp.HasMin() {
+		obj.AddField("min").SetFloat64(dp.Min())
+	}
+	if dp.HasMax() {
+		obj.AddField("max").SetFloat64(dp.Max())
+	}
+	obj.AddField("scale").SetInt32(dp.Scale())
+	obj.AddField("zero_count").SetInt64(int64(dp.ZeroCount()))
+	obj.AddField("positive_offset").SetInt32(dp.Positive().Offset())
+	setUInt64List(obj, "positive_bucket_counts", dp.Positive().BucketCounts())
+	obj.AddField("negative_offset").SetInt32(dp.Negative().Offset())
+	setUInt64List(obj, "negative_bucket_counts", dp.Negative().BucketCounts())
+}
+
+func marshalSummaryDataPoint(obj interfaces.MarshalObject, dp pmetric.SummaryDataPoint) {
+	setCommonDataPointFields(obj, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+	obj.AddField("count").SetInt64(int64(dp.Count()))
+	obj.AddField("sum").SetFloat64(dp.Sum())
+	if dp.QuantileValues().Len() > 0 {
+		list := obj.AddField("quantile_values").List()
+		for i := 0; i < dp.QuantileValues().Len(); i++ {
+			qv := dp.QuantileValues().At(i)
+			group := list.Add().Group()
+			group.AddField("quantile").SetFloat64(qv.Quantile())
+			group.AddField("value").SetFloat64(qv.Value())
+		}
+	}
+}
+
+func setUInt64List(obj interfaces.MarshalObject, field string, values pcommon.UInt64Slice) {
+	if values.Len() == 0 {
+		return
+	}
+	list := obj.AddField(field).List()
+	for i := 0; i < values.Len(); i++ {
+		list.Add().SetInt64(int64(values.At(i)))
+	}
+}
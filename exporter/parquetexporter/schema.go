@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// The schemas below are documented in the README. Every row is
+// denormalized: resource and instrumentation scope information is repeated
+// for each span, data point and log record so that files can be queried
+// without joins. Attributes are stored as string maps, values of
+// non-string attributes are converted using their JSON representation.
+
+const attributesGroup = `(MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			optional binary value (STRING);
+		}
+	}`
+
+const resourceAndScopeColumns = `
+	optional group resource_attributes ` + attributesGroup + `
+	optional binary resource_schema_url (STRING);
+	optional binary scope_name (STRING);
+	optional binary scope_version (STRING);
+	optional group scope_attributes ` + attributesGroup + `
+	optional binary scope_schema_url (STRING);`
+
+const spansSchema = `message spans {` + resourceAndScopeColumns + `
+	required binary trace_id (STRING);
+	required binary span_id (STRING);
+	optional binary parent_span_id (STRING);
+	optional binary trace_state (STRING);
+	required binary name (STRING);
+	required binary kind (STRING);
+	required int64 start_time_unix_nano (TIMESTAMP(NANOS, true));
+	required int64 end_time_unix_nano (TIMESTAMP(NANOS, true));
+	required int64 duration_nano;
+	optional group attributes ` + attributesGroup + `
+	required int64 dropped_attributes_count;
+	optional group events (LIST) {
+		repeated group list {
+			required group element {
+				required int64 time_unix_nano (TIMESTAMP(NANOS, true));
+				required binary name (STRING);
+				optional group attributes ` + attributesGroup + `
+				required int64 dropped_attributes_count;
+			}
+		}
+	}
+	required int64 dropped_events_count;
+	optional group links (LIST) {
+		repeated group list {
+			required group element {
+				required binary trace_id (STRING);
+				required binary span_id (STRING);
+				optional binary trace_state (STRING);
+				optional group attributes ` + attributesGroup + `
+				required int64 dropped_attributes_count;
+			}
+		}
+	}
+	required int64 dropped_links_count;
+	required binary status_code (STRING);
+	optional binary status_message (STRING);
+}`
+
+const dataPointsSchema = `message data_points {` + resourceAndScopeColumns + `
+	required binary metric_name (STRING);
+	optional binary metric_description (STRING);
+	optional binary metric_unit (STRING);
+	required binary metric_type (STRING);
+	optional binary aggregation_temporality (STRING);
+	optional boolean is_monotonic;
+	optional group attributes ` + attributesGroup + `
+	optional int64 start_time_unix_nano (TIMESTAMP(NANOS, true));
+	required int64 time_unix_nano (TIMESTAMP(NANOS, true));
+	required int64 flags;
+	optional double value_double;
+	optional int64 value_int;
+	optional int64 count;
+	optional double sum;
+	optional double min;
+	optional double max;
+	optional group bucket_counts (LIST) {
+		repeated group list {
+			required int64 element;
+		}
+	}
+	optional group explicit_bounds (LIST) {
+		repeated group list {
+			required double element;
+		}
+	}
+	optional int32 scale;
+	optional int64 zero_count;
+	optional int32 positive_offset;
+	optional group positive_bucket_counts (LIST) {
+		repeated group list {
+			required int64 element;
+		}
+	}
+	optional int32 negative_offset;
+	optional group negative_bucket_counts (LIST) {
+		repeated group list {
+			required int64 element;
+		}
+	}
+	optional group quantile_values (LIST) {
+		repeated group list {
+			required group element {
+				required double quantile;
+				required double value;
+			}
+		}
+	}
+}`
+
+const logsSchema = `message logs {` + resourceAndScopeColumns + `
+	optional int64 time_unix_nano (TIMESTAMP(NANOS, true));
+	optional int64 observed_time_unix_nano (TIMESTAMP(NANOS, true));
+	optional binary trace_id (STRING);
+	optional binary span_id (STRING);
+	required int64 flags;
+	required int32 severity_number;
+	optional binary severity_text (STRING);
+	optional binary body (STRING);
+	optional group attributes ` + attributesGroup + `
+	required int64 dropped_attributes_count;
+}`
+
+// resourceScope holds the columns shared by all records of one resource and
+// instrumentation scope.
+type resourceScope struct {
+	resource          pcommon.Resource
+	resourceSchemaURL string
+	scope             pcommon.InstrumentationScope
+	scopeSchemaURL    string
+}
+
+func (rs resourceScope) marshal(obj interfaces.MarshalObject) {
+	setAttributes(obj, "resource_attributes", rs.resource.Attributes())
+	setString(obj, "resource_schema_url", rs.resourceSchemaURL)
+	setString(obj, "scope_name", rs.scope.Name())
+	setString(obj, "scope_version", rs.scope.Version())
+	setAttributes(obj, "scope_attributes", rs.scope.Attributes())
+	setString(obj, "scope_schema_url", rs.scopeSchemaURL)
+}
+
+// setAttributes writes the attribute map as a MAP column. Empty maps are
+// left out so they read back as null.
+func setAttributes(obj interfaces.MarshalObject, field string, attrs pcommon.Map) {
+	if attrs.Len() == 0 {
+		return
+	}
+	m := obj.AddField(field).Map()
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		kv := m.Add()
+		kv.Key().SetByteArray([]byte(k))
+		kv.Value().SetByteArray([]byte(v.AsString()))
+		return true
+	})
+}
+
+// setString writes an optional string column, leaving it null when empty.
+func setString(obj interfaces.MarshalObject, field string, value string) {
+	if value == "" {
+		return
+	}
+	obj.AddField(field).SetByteArray([]byte(value))
+}
+
+// setTimestamp writes an optional timestamp column, leaving it null when unset.
+func setTimestamp(obj interfaces.MarshalObject, field string, ts pcommon.Timestamp) {
+	if ts == 0 {
+		return
+	}
+	obj.AddField(field).SetInt64(int64(ts))
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanRecord is a single row of the spans schema.
+type spanRecord struct {
+	resourceScope
+	span ptrace.Span
+}
+
+func spanRecords(td ptrace.Traces) []interfaces.Marshaller {
+	records := make([]interfaces.Marshaller, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			scope := resourceScope{
+				resource:          rs.Resource(),
+				resourceSchemaURL: rs.SchemaUrl(),
+				scope:             ils.Scope(),
+				scopeSchemaURL:    ils.SchemaUrl(),
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				records = append(records, &spanRecord{resourceScope: scope, span: spans.At(k)})
+			}
+		}
+	}
+	return records
+}
+
+func (r *spanRecord) MarshalParquet(obj interfaces.MarshalObject) error {
+	r.resourceScope.marshal(obj)
+
+	span := r.span
+	obj.AddField("trace_id").SetByteArray([]byte(span.TraceID().HexString()))
+	obj.AddField("span_id").SetByteArray([]byte(span.SpanID().HexString()))
+	if !span.ParentSpanID().IsEmpty() {
+		obj.AddField("parent_span_id").SetByteArray([]byte(span.ParentSpanID().HexString()))
+	}
+	setString(obj, "trace_state", span.TraceStateStruct().AsRaw())
+	obj.AddField("name").SetByteArray([]byte(span.Name()))
+	obj.AddField("kind").SetByteArray([]byte(span.Kind().String()))
+	obj.AddField("start_time_unix_nano").SetInt64(int64(span.StartTimestamp()))
+	obj.AddField("end_time_unix_nano").SetInt64(int64(span.EndTimestamp()))
+	obj.AddField("duration_nano").SetInt64(int64(span.EndTimestamp()) - int64(span.StartTimestamp()))
+	setAttributes(obj, "attributes", span.Attributes())
+	obj.AddField("dropped_attributes_count").SetInt64(int64(span.DroppedAttributesCount()))
+
+	if span.Events().Len() > 0 {
+		events := obj.AddField("events").List()
+		for i := 0; i < span.Events().Len(); i++ {
+			event := span.Events().At(i)
+			group := events.Add().Group()
+			group.AddField("time_unix_nano").SetInt64(int64(event.Timestamp()))
+			group.AddField("name").SetByteArray([]byte(event.Name()))
+			setAttributes(group, "attributes", event.Attributes())
+			group.AddField("dropped_attributes_count").SetInt64(int64(event.DroppedAttributesCount()))
+		}
+	}
+	obj.AddField("dropped_events_count").SetInt64(int64(span.DroppedEventsCount()))
+
+	if span.Links().Len() > 0 {
+		links := obj.AddField("links").List()
+		for i := 0; i < span.Links().Len(); i++ {
+			link := span.Links().At(i)
+			group := links.Add().Group()
+			group.AddField("trace_id").SetByteArray([]byte(link.TraceID().HexString()))
+			group.AddField("span_id").SetByteArray([]byte(link.SpanID().HexString()))
+			setString(group, "trace_state", link.TraceStateStruct().AsRaw())
+			setAttributes(group, "attributes", link.Attributes())
+			group.AddField("dropped_attributes_count").SetInt64(int64(link.DroppedAttributesCount()))
+		}
+	}
+	obj.AddField("dropped_links_count").SetInt64(int64(span.DroppedLinksCount()))
+
+	obj.AddField("status_code").SetByteArray([]byte(span.Status().Code().String()))
+	setString(obj, "status_message", span.Status().Message())
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"go.uber.org/multierr"
+)
+
+const (
+	fileExtension = ".parquet"
+	// inProgressSuffix is appended to the name of the file currently being
+	// written, so that readers globbing for *.parquet only pick up complete files.
+	inProgressSuffix = ".inprogress"
+	// timeFormat is used to make the file names unique and sortable.
+	timeFormat = "2006-01-02T15-04-05.000000000"
+	// timeGlob matches the timestamps formatted with timeFormat, so that the
+	// files of a prefix are not mistaken for those of a longer one.
+	timeGlob = "[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]-[0-9][0-9]-[0-9][0-9].[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]"
+)
+
+// rollingWriter writes records of a single schema to a sequence of Parquet
+// files in a directory, starting a new file whenever the configured size or
+// age limit is reached. Files are only created once there is data to write.
+//
+// The Parquet file writer encodes into a buffer that is then written to the
+// file. An error writing to the file leaves the data in the buffer, so that
+// the file can still be completed once writing to it succeeds again.
+type rollingWriter struct {
+	dir           string
+	prefix        string
+	opts          []goparquet.FileWriterOption
+	rowGroupBytes int64
+	maxBytes      int64
+	maxAge        time.Duration
+	localTime     bool
+	now           func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	// fw is nil once the current file has been finalized, even if its
+	// buffered data could not be written to it yet.
+	fw       *goparquet.FileWriter
+	buf      bytes.Buffer
+	name     string
+	openedAt time.Time
+}
+
+func newRollingWriter(dir, prefix string, rowGroupBytes int64, rotation Rotation, opts ...goparquet.FileWriterOption) *rollingWriter {
+	return &rollingWriter{
+		dir:           dir,
+		prefix:        prefix,
+		opts:          opts,
+		rowGroupBytes: rowGroupBytes,
+		maxBytes:      int64(rotation.MaxMegabytes) * 1024 * 1024,
+		maxAge:        rotation.MaxAge,
+		localTime:     rotation.LocalTime,
+		now:           time.Now,
+	}
+}
+
+// write appends the records to the current file, opening a new one if
+// needed, and rotates the file once it exceeds the size limit. Either all
+// the records are written or none of them, so that a batch that failed can
+// be retried without duplicating rows.
+func (w *rollingWriter) write(records []interfaces.Marshaller) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	rows, err := w.marshal(records)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// The batch is rejected as long as the data of the previous ones cannot
+	// be written to the file.
+	if err := w.writeBuffer(); err != nil {
+		return err
+	}
+	if w.fw != nil && w.expired() {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+	if w.fw == nil {
+		if err := w.openFile(); err != nil {
+			return err
+		}
+	}
+
+	// The rows were checked by marshal and are only kept in memory here, the
+	// row group is only flushed between batches.
+	for _, row := range rows {
+		if err := w.fw.AddData(row); err != nil {
+			return err
+		}
+	}
+	if w.fw.CurrentRowGroupSize() >= w.rowGroupBytes {
+		if err := w.fw.FlushRowGroup(); err != nil {
+			return err
+		}
+	}
+	if w.maxBytes > 0 && w.fw.CurrentFileSize()+w.fw.CurrentRowGroupSize() >= w.maxBytes {
+		if err := w.fw.Close(); err != nil {
+			return err
+		}
+		w.fw = nil
+	}
+
+	// The batch is part of the file from now on, so an error writing it out
+	// is not returned for it: the data stays buffered and the next write,
+	// rotation or close tries again and returns the error.
+	_ = w.writeBuffer()
+	return nil
+}
+
+// marshal converts the records to rows and checks them against the schema
+// with a scratch writer, before any of them is added to the current file.
+func (w *rollingWriter) marshal(records []interfaces.Marshaller) ([]map[string]interface{}, error) {
+	scratch := goparquet.NewFileWriter(io.Discard, w.fileWriterOptions()...)
+	schemaDef := scratch.GetSchemaDefinition()
+
+	rows := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		obj := interfaces.NewMarshallObjectWithSchema(nil, schemaDef)
+		if err := r.MarshalParquet(obj); err != nil {
+			return nil, err
+		}
+		row := obj.GetData()
+		if err := scratch.AddData(row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// fileWriterOptions returns the options of the writer with automatic row
+// group flushing disabled, as row groups are flushed between batches.
+func (w *rollingWriter) fileWriterOptions() []goparquet.FileWriterOption {
+	opts := make([]goparquet.FileWriterOption, 0, len(w.opts)+1)
+	opts = append(opts, w.opts...)
+	return append(opts, goparquet.WithMaxRowGroupSize(0))
+}
+
+// rotateIfExpired closes the current file if it has been open longer than
+// the maximum age, so that data becomes visible even when traffic stops.
+func (w *rollingWriter) rotateIfExpired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.writeBuffer(); err != nil {
+		return err
+	}
+	if w.fw == nil || !w.expired() {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *rollingWriter) expired() bool {
+	return w.maxAge > 0 && w.now().Sub(w.openedAt) >= w.maxAge
+}
+
+// close finalizes the current file, if any.
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var err error
+	if w.fw == nil {
+		err = w.writeBuffer()
+	} else {
+		err = w.closeFile()
+	}
+	if err != nil && w.file != nil {
+		// Nothing tries again after close, the file is left in progress.
+		err = multierr.Append(err, w.file.Close())
+		w.file = nil
+	}
+	return err
+}
+
+func (w *rollingWriter) openFile() error {
+	w.openedAt = w.now()
+	ts := w.openedAt
+	if !w.localTime {
+		ts = ts.UTC()
+	}
+	w.name = filepath.Join(w.dir, fmt.Sprintf("%s-%s%s", w.prefix, ts.Format(timeFormat), fileExtension))
+
+	f, err := os.OpenFile(w.name+inProgressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.buf.Reset()
+	w.fw = goparquet.NewFileWriter(&w.buf, w.fileWriterOptions()...)
+	return nil
+}
+
+// closeFile finalizes the current file and writes it out.
+func (w *rollingWriter) closeFile() error {
+	if err := w.fw.Close(); err != nil {
+		return err
+	}
+	w.fw = nil
+	return w.writeBuffer()
+}
+
+// writeBuffer writes the buffered data to the current file, and renames the
+// file once it is finalized and all of its data is written. Data that could
+// not be written is kept in the buffer.
+func (w *rollingWriter) writeBuffer() error {
+	if w.file == nil {
+		return nil
+	}
+	if _, err := w.buf.WriteTo(w.file); err != nil {
+		return err
+	}
+	if w.fw != nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return err
+	}
+	return os.Rename(w.name+inProgressSuffix, w.name)
+}
+
+// removeInProgressFiles removes the files of the writers with the prefix
+// that were left in progress in the directory, by a crash for instance.
+// Such files have no footer, so they cannot be read nor finalized. It
+// returns the names of the removed files.
+func removeInProgressFiles(dir, prefix string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, prefix+"-"+timeGlob+fileExtension+inProgressSuffix))
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, name := range names {
+		if err := os.Remove(name); err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
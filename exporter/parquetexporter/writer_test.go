@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/floor/interfaces"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	value string
+}
+
+func (r *testRecord) MarshalParquet(obj interfaces.MarshalObject) error {
+	obj.AddField("value").SetByteArray([]byte(r.value))
+	return nil
+}
+
+func newTestRollingWriter(t *testing.T, rotation Rotation) *rollingWriter {
+	schemaDef, err := parquetschema.ParseSchemaDefinition(`message test { required binary value (STRING); }`)
+	require.NoError(t, err)
+	return newRollingWriter(t.TempDir(), "test", 1024*1024, rotation, goparquet.WithSchemaDefinition(schemaDef))
+}
+
+func TestRollingWriterInProgressFile(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{})
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "a"}}))
+
+	inProgress, err := filepath.Glob(filepath.Join(w.dir, "*"+fileExtension+inProgressSuffix))
+	require.NoError(t, err)
+	assert.Len(t, inProgress, 1)
+	assert.Empty(t, parquetFiles(t, w.dir))
+
+	require.NoError(t, w.close())
+	inProgress, err = filepath.Glob(filepath.Join(w.dir, "*"+fileExtension+inProgressSuffix))
+	require.NoError(t, err)
+	assert.Empty(t, inProgress)
+	assert.Len(t, parquetFiles(t, w.dir), 1)
+}
+
+func TestRollingWriterRotatesOnSize(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{})
+	// Use a tiny limit so that every write exceeds it.
+	w.maxBytes = 1
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "a"}}))
+	}
+	require.NoError(t, w.close())
+
+	files := parquetFiles(t, w.dir)
+	require.Len(t, files, 3)
+	assert.Equal(t, filepath.Join(w.dir, "test-2022-10-01T12-00-00.001000000.parquet"), files[0])
+	for _, f := range files {
+		assert.Len(t, readRows(t, f), 1)
+	}
+}
+
+func TestRollingWriterRotatesOnAge(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{MaxAge: time.Minute})
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "a"}}))
+	require.NoError(t, w.rotateIfExpired())
+	assert.Empty(t, parquetFiles(t, w.dir))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "b"}}))
+	assert.Len(t, parquetFiles(t, w.dir), 1)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, w.rotateIfExpired())
+	files := parquetFiles(t, w.dir)
+	require.Len(t, files, 2)
+	assert.Equal(t, []byte("a"), readRows(t, files[0])[0]["value"])
+	assert.Equal(t, []byte("b"), readRows(t, files[1])[0]["value"])
+
+	_, err := os.Stat(w.name + inProgressSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
+
+type invalidRecord struct{}
+
+func (r *invalidRecord) MarshalParquet(obj interfaces.MarshalObject) error {
+	// The value does not have the type of the schema
+	obj.AddField("value").SetInt64(1)
+	return nil
+}
+
+func TestRollingWriterWritesBatchesCompletely(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{})
+
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "a"}}))
+	require.Error(t, w.write([]interfaces.Marshaller{&testRecord{value: "b"}, &invalidRecord{}}))
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "c"}}))
+	require.NoError(t, w.close())
+
+	files := parquetFiles(t, w.dir)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0])
+	require.Len(t, rows, 2)
+	assert.Equal(t, []byte("a"), rows[0]["value"])
+	assert.Equal(t, []byte("c"), rows[1]["value"])
+}
+
+// failWrites makes writing to the current file of the writer fail and
+// returns a function that restores it.
+func failWrites(t *testing.T, w *rollingWriter) func() {
+	f := w.file
+	readOnly, err := os.Open(w.name + inProgressSuffix)
+	require.NoError(t, err)
+	w.file = readOnly
+	return func() {
+		w.file = f
+		require.NoError(t, readOnly.Close())
+	}
+}
+
+func TestRollingWriterKeepsDataOnWriteError(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{})
+	// Flush every batch to its own row group.
+	w.rowGroupBytes = 1
+
+	// Values larger than the buffer of the Parquet writer are written out right away
+	a := strings.Repeat("a", 8192)
+	b := strings.Repeat("b", 8192)
+	c := strings.Repeat("c", 8192)
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: a}}))
+
+	restore := failWrites(t, w)
+	// The batch is part of the file even though it cannot be written out yet
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: b}}))
+	require.Error(t, w.write([]interfaces.Marshaller{&testRecord{value: c}}))
+	assert.Empty(t, parquetFiles(t, w.dir))
+
+	restore()
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: c}}))
+	require.NoError(t, w.close())
+
+	files := parquetFiles(t, w.dir)
+	require.Len(t, files, 1)
+	rows := readRows(t, files[0])
+	require.Len(t, rows, 3)
+	assert.Equal(t, []byte(a), rows[0]["value"])
+	assert.Equal(t, []byte(b), rows[1]["value"])
+	assert.Equal(t, []byte(c), rows[2]["value"])
+}
+
+func TestRollingWriterCompletesFileAfterWriteError(t *testing.T) {
+	w := newTestRollingWriter(t, Rotation{MaxAge: time.Minute})
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.write([]interfaces.Marshaller{&testRecord{value: "a"}}))
+	restore := failWrites(t, w)
+	now = now.Add(time.Minute)
+	require.Error(t, w.rotateIfExpired())
+	assert.Empty(t, parquetFiles(t, w.dir))
+	_, err := os.Stat(w.name + inProgressSuffix)
+	require.NoError(t, err)
+
+	restore()
+	require.NoError(t, w.rotateIfExpired())
+	files := parquetFiles(t, w.dir)
+	require.Len(t, files, 1)
+	assert.Equal(t, []byte("a"), readRows(t, files[0])[0]["value"])
+}
+
+func TestRemoveInProgressFiles(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{
+		"test-2022-10-01T12-00-00.000000000.parquet.inprogress",
+		"test-2022-10-01T12-00-01.000000000.parquet",
+		"other-2022-10-01T12-00-00.000000000.parquet.inprogress",
+		"test-other-2022-10-01T12-00-00.000000000.parquet.inprogress",
+	}
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+
+	removed, err := removeInProgressFiles(dir, "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, names[0])}, removed)
+
+	_, err = os.Stat(filepath.Join(dir, names[0]))
+	assert.True(t, os.IsNotExist(err))
+	for _, name := range names[1:] {
+		_, err = os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err)
+	}
+}
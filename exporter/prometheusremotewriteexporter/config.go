@@ -51,6 +51,9 @@ type Config struct {
 
 	// TargetInfo allows customizing the target_info metric
 	TargetInfo *TargetInfo `mapstructure:"target_info,omitempty"`
+
+	// ExponentialHistograms allows exporting exponential histograms as histograms with explicit bounds
+	ExponentialHistograms ExponentialHistograms `mapstructure:"exponential_histograms"`
 }
 
 type TargetInfo struct {
@@ -58,6 +61,16 @@ type TargetInfo struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+type ExponentialHistograms struct {
+	// Enabled if false exponential histograms are dropped by the exporter
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxBuckets is the maximum number of positive and negative buckets an
+	// exponential histogram is exported with, its scale is reduced until its
+	// buckets fit. There is no limit if it is 0.
+	MaxBuckets int `mapstructure:"max_buckets"`
+}
+
 // RemoteWriteQueue allows to configure the remote write queue.
 type RemoteWriteQueue struct {
 	// Enabled if false the queue is not enabled, the export requests
@@ -91,6 +104,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	if cfg.ExponentialHistograms.MaxBuckets < 0 {
+		return fmt.Errorf("exponential histogram max buckets can't be negative")
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
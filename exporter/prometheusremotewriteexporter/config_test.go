@@ -98,6 +98,10 @@ func Test_loadConfigDefaultAutomaticRename(t *testing.T) {
 			TargetInfo: &TargetInfo{
 				Enabled: true,
 			},
+			ExponentialHistograms: ExponentialHistograms{
+				Enabled:    true,
+				MaxBuckets: 100,
+			},
 		})
 }
 
@@ -121,6 +125,16 @@ func TestNegativeNumConsumers(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNegativeExponentialHistogramMaxBuckets(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "negative_exponential_histogram_max_buckets.yaml"), factories)
+	assert.Error(t, err)
+}
+
 func TestDisabledQueue(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	assert.NoError(t, err)
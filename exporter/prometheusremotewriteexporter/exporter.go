@@ -55,6 +55,9 @@ type prwExporter struct {
 	settings          component.TelemetrySettings
 	disableTargetInfo bool
 
+	exportExponentialHistograms    bool
+	exponentialHistogramMaxBuckets int
+
 	wal *prweWAL
 }
 
@@ -83,6 +86,9 @@ func newPRWExporter(cfg *Config, set component.ExporterCreateSettings) (*prwExpo
 		clientSettings:    &cfg.HTTPClientSettings,
 		settings:          set.TelemetrySettings,
 		disableTargetInfo: !cfg.TargetInfo.Enabled,
+
+		exportExponentialHistograms:    cfg.ExponentialHistograms.Enabled,
+		exponentialHistogramMaxBuckets: cfg.ExponentialHistograms.MaxBuckets,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -135,7 +141,13 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{Namespace: prwe.namespace, ExternalLabels: prwe.externalLabels, DisableTargetInfo: prwe.disableTargetInfo})
+		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{
+			Namespace:                      prwe.namespace,
+			ExternalLabels:                 prwe.externalLabels,
+			DisableTargetInfo:              prwe.disableTargetInfo,
+			ExportExponentialHistograms:    prwe.exportExponentialHistograms,
+			ExponentialHistogramMaxBuckets: prwe.exponentialHistogramMaxBuckets,
+		})
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
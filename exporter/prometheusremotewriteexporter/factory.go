@@ -108,6 +108,9 @@ func createDefaultConfig() config.Exporter {
 			QueueSize:    10000,
 			NumConsumers: 5,
 		},
+		ExponentialHistograms: ExponentialHistograms{
+			MaxBuckets: 160,
+		},
 		TargetInfo: &TargetInfo{
 			Enabled: true,
 		},
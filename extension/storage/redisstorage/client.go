@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type redisStorageClient struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+}
+
+func newClient(client *redis.Client, prefix string, expiration time.Duration) *redisStorageClient {
+	return &redisStorageClient{
+		client:     client,
+		prefix:     prefix,
+		expiration: expiration,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.WithContext(ctx).Get(c.prefixKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.WithContext(ctx).Set(c.prefixKey(key), value, c.expiration).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisStorageClient) Delete(ctx context.Context, key string) error {
+	return c.client.WithContext(ctx).Del(c.prefixKey(key)).Err()
+}
+
+// Batch executes the specified operations in order, sending them to the server
+// in a single pipeline. Get operation results are updated in place
+func (c *redisStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	pipe := c.client.WithContext(ctx).Pipeline()
+	defer pipe.Close()
+
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			pipe.Get(c.prefixKey(op.Key))
+		case storage.Set:
+			pipe.Set(c.prefixKey(op.Key), op.Value, c.expiration)
+		case storage.Delete:
+			pipe.Del(c.prefixKey(op.Key))
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+
+	// Exec only returns the error of the first failed command, which may be the
+	// redis.Nil of a missing key, so the error of each command is checked instead
+	cmds, err := pipe.Exec()
+	if len(cmds) != len(ops) {
+		return err
+	}
+
+	for i, cmd := range cmds {
+		if ops[i].Type != storage.Get {
+			if err := cmd.Err(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := cmd.(*redis.StringCmd).Bytes()
+		switch {
+		case errors.Is(err, redis.Nil):
+			ops[i].Value = nil
+		case err != nil:
+			return err
+		default:
+			ops[i].Value = value
+		}
+	}
+	return nil
+}
+
+// Close is a no-op, the connection to the server is shared with the other
+// clients and closed when the extension shuts down
+func (c *redisStorageClient) Close(_ context.Context) error {
+	return nil
+}
+
+func (c *redisStorageClient) prefixKey(key string) string {
+	return c.prefix + key
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, miniredis.RunT(t), 0)
+
+	testKey := "testKey"
+	testValue := []byte("testValue")
+
+	// Make sure nothing is there
+	value, err := client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Set it
+	err = client.Set(ctx, testKey, testValue)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// Delete it
+	err = client.Delete(ctx, testKey)
+	require.NoError(t, err)
+
+	// Make sure it's gone
+	value, err = client.Get(ctx, testKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientBatchOperations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, miniredis.RunT(t), 0)
+
+	testSetEntries := []storage.Operation{
+		storage.SetOperation("testKey1", []byte("testValue1")),
+		storage.SetOperation("testKey2", []byte("testValue2")),
+	}
+
+	testGetEntries := []storage.Operation{
+		storage.GetOperation("testKey1"),
+		storage.GetOperation("testKey2"),
+	}
+
+	// Make sure nothing is there
+	err := client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	for _, op := range testGetEntries {
+		require.Nil(t, op.Value)
+	}
+
+	// Set it
+	err = client.Batch(ctx, testSetEntries...)
+	require.NoError(t, err)
+
+	// Get it back out, make sure it's right
+	err = client.Batch(ctx, testGetEntries...)
+	require.NoError(t, err)
+	for i := range testGetEntries {
+		require.Equal(t, testSetEntries[i].Key, testGetEntries[i].Key)
+		require.Equal(t, testSetEntries[i].Value, testGetEntries[i].Value)
+	}
+
+	// Update it, delete the other key and read both in the same batch, in order
+	testMixedEntries := []storage.Operation{
+		storage.SetOperation("testKey1", []byte("testValue3")),
+		storage.DeleteOperation("testKey2"),
+		storage.GetOperation("testKey1"),
+		storage.GetOperation("testKey2"),
+	}
+	err = client.Batch(ctx, testMixedEntries...)
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue3"), testMixedEntries[2].Value)
+	require.Nil(t, testMixedEntries[3].Value)
+}
+
+func TestClientExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server, time.Minute)
+
+	require.NoError(t, client.Set(ctx, "testKey1", []byte("testValue1")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("testKey2", []byte("testValue2"))))
+	assert.Equal(t, time.Minute, server.TTL("test_testKey1"))
+	assert.Equal(t, time.Minute, server.TTL("test_testKey2"))
+
+	server.FastForward(30 * time.Second)
+
+	// Setting a key again refreshes its expiration
+	require.NoError(t, client.Set(ctx, "testKey1", []byte("testValue1")))
+
+	server.FastForward(45 * time.Second)
+
+	value, err := client.Get(ctx, "testKey1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("testValue1"), value)
+
+	value, err = client.Get(ctx, "testKey2")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestClientWithoutExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server, 0)
+
+	require.NoError(t, client.Set(ctx, "testKey", []byte("testValue")))
+	assert.Equal(t, time.Duration(0), server.TTL("test_testKey"))
+}
+
+func TestClientBatchFailure(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server, 0)
+
+	server.SetError("server unavailable")
+	err := client.Batch(ctx, storage.SetOperation("testKey", []byte("testValue")), storage.GetOperation("testKey"))
+	require.Error(t, err)
+}
+
+func TestClientBatchFailureAfterMissingKey(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, miniredis.RunT(t), 0)
+	client.client.AddHook(readOnlyHook{})
+
+	// The Get of the missing key fails first with redis.Nil, which must not hide the failed Set
+	err := client.Batch(ctx, storage.GetOperation("testKey1"), storage.SetOperation("testKey2", []byte("testValue2")))
+	require.EqualError(t, err, "READONLY You can't write against a read only replica.")
+}
+
+// readOnlyHook replaces the replies to the SET commands of pipelines with
+// the error returned by a read only replica
+type readOnlyHook struct{}
+
+func (readOnlyHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (readOnlyHook) AfterProcess(context.Context, redis.Cmder) error {
+	return nil
+}
+
+func (readOnlyHook) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (readOnlyHook) AfterProcessPipeline(_ context.Context, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		if cmd.Name() == "set" {
+			cmd.SetErr(errors.New("READONLY You can't write against a read only replica."))
+		}
+	}
+	return nil
+}
+
+func newTestClient(t *testing.T, server *miniredis.Miniredis, expiration time.Duration) *redisStorageClient {
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		require.NoError(t, redisClient.Close())
+	})
+	return newClient(redisClient, "test_", expiration)
+}
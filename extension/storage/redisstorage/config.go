@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for redis storage extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the address of the Redis server, in the host:port form.
+	Endpoint string `mapstructure:"endpoint"`
+	// Password is the optional password, matching the requirepass server configuration option.
+	Password string `mapstructure:"password"`
+	// DB is the Redis database to select after connecting.
+	DB int `mapstructure:"db"`
+	// Prefix is prepended to all the keys written by this extension, ahead of the per-component prefix.
+	Prefix string `mapstructure:"prefix"`
+	// Expiration is the time to live of each key, refreshed every time the key is set.
+	// Keys never expire if it is zero.
+	Expiration time.Duration `mapstructure:"expiration"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("missing endpoint for %s", cfg.ID())
+	}
+	if cfg.DB < 0 {
+		return errors.New("db must be non-negative")
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration must be non-negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstorage
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestExtensionIntegrity(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t, miniredis.RunT(t).Addr(), "")
+	err := se.Start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err)
+	defer func() {
+		err = se.Shutdown(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	type mockComponent struct {
+		kind component.Kind
+		name config.ComponentID
+	}
+
+	components := []mockComponent{
+		{kind: component.KindReceiver, name: newTestEntity("receiver_one")},
+		{kind: component.KindReceiver, name: newTestEntity("receiver_two")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_one")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_two")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_one")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_two")},
+		{kind: component.KindExtension, name: newTestEntity("extension_one")},
+		{kind: component.KindExtension, name: newTestEntity("extension_two")},
+	}
+
+	// Make a client for each component
+	clients := make(map[config.ComponentID]storage.Client)
+	for _, c := range components {
+		client, err := se.GetClient(ctx, c.kind, c.name, "")
+		require.NoError(t, err)
+		clients[c.name] = client
+	}
+
+	thrashClient := func(wg *sync.WaitGroup, n config.ComponentID, c storage.Client) {
+		// keys and values
+		keys := []string{"a", "b", "c", "d", "e"}
+		myBytes := []byte(n.Name())
+
+		// Set my values
+		for i := 0; i < len(keys); i++ {
+			err := c.Set(ctx, keys[i], myBytes)
+			require.NoError(t, err)
+		}
+
+		// Repeatedly thrash client
+		for j := 0; j < 100; j++ {
+
+			// Make sure my values are still mine
+			for i := 0; i < len(keys); i++ {
+				v, err := c.Get(ctx, keys[i])
+				require.NoError(t, err)
+				require.Equal(t, myBytes, v)
+			}
+
+			// Delete my values
+			for i := 0; i < len(keys); i++ {
+				err := c.Delete(ctx, keys[i])
+				require.NoError(t, err)
+			}
+
+			// Reset my values
+			for i := 0; i < len(keys); i++ {
+				err := c.Set(ctx, keys[i], myBytes)
+				require.NoError(t, err)
+			}
+		}
+		c.Close(ctx)
+		wg.Done()
+	}
+
+	// Use clients concurrently
+	var wg sync.WaitGroup
+	for name, client := range clients {
+		wg.Add(1)
+		go thrashClient(&wg, name, client)
+	}
+	wg.Wait()
+}
+
+func TestExtensionKeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	se := newTestExtension(t, server.Addr(), "gateway_")
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my component"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	namedClient, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "queue")
+	require.NoError(t, err)
+	require.NoError(t, namedClient.Set(ctx, "key", []byte("other value")))
+
+	assert.ElementsMatch(t, []string{
+		"gateway_receiver_nop_mycomponent_key",
+		"gateway_exporter_nop_my_component_queue_key",
+	}, server.Keys())
+}
+
+func TestExtensionStartFailure(t *testing.T) {
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	se := newTestExtension(t, addr, "")
+	require.Error(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, se.Shutdown(context.Background()))
+}
+
+func newTestExtension(t *testing.T, endpoint string, prefix string) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Prefix = prefix
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	return se
+}
+
+func newTestEntity(name string) config.ComponentID {
+	return config.NewComponentIDWithName("nop", name)
+}
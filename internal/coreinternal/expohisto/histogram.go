@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expohisto // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of buckets for the
+	// positive and for the negative values.
+	DefaultMaxSize = 160
+	// MinSize is the smallest maximum number of buckets allowing values of
+	// any magnitude to be recorded.
+	MinSize = 2
+	// MaxScale is the largest scale, at which the bucket boundaries are the
+	// closest to each other.
+	MaxScale = 20
+	// MinScale is the smallest scale, at which all the finite values fit
+	// into MinSize buckets.
+	MinScale = -10
+)
+
+// Histogram aggregates values into an exponential histogram. The values are
+// recorded at the largest scale for which the buckets fit into the maximum
+// size, the scale is reduced whenever a new value does not fit anymore.
+// A Histogram is not safe for concurrent use.
+type Histogram struct {
+	maxSize int32
+	scale   int32
+
+	positive  buckets
+	negative  buckets
+	zeroCount uint64
+
+	count uint64
+	sum   float64
+	min   float64
+	max   float64
+}
+
+// New creates an empty histogram holding at most maxSize buckets for each
+// sign, starting at maxScale. maxSize must be at least MinSize and maxScale
+// must be between MinScale and MaxScale.
+func New(maxSize int32, maxScale int32) *Histogram {
+	return &Histogram{
+		maxSize: maxSize,
+		scale:   maxScale,
+	}
+}
+
+// Update records a value. NaN and infinite values are ignored.
+func (h *Histogram) Update(v float64) {
+	h.UpdateByIncr(v, 1)
+}
+
+// UpdateByIncr records a value incr times, such as a sampled value standing
+// for several occurrences. NaN and infinite values are ignored.
+func (h *Histogram) UpdateByIncr(v float64, incr uint64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) || incr == 0 {
+		return
+	}
+
+	if h.count == 0 || v < h.min {
+		h.min = v
+	}
+	if h.count == 0 || v > h.max {
+		h.max = v
+	}
+	h.count += incr
+	h.sum += v * float64(incr)
+
+	switch {
+	case v > 0:
+		h.record(&h.positive, v, incr)
+	case v < 0:
+		h.record(&h.negative, -v, incr)
+	default:
+		h.zeroCount += incr
+	}
+}
+
+func (h *Histogram) record(b *buckets, v float64, incr uint64) {
+	index := mapToIndex(v, h.scale)
+	if change := b.scaleChange(index, h.maxSize); change > 0 {
+		h.scale -= change
+		h.positive.downscale(change)
+		h.negative.downscale(change)
+		index >>= change
+	}
+	b.increment(index, incr)
+}
+
+// Count returns the number of recorded values.
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+// Scale returns the current scale of the histogram.
+func (h *Histogram) Scale() int32 {
+	return h.scale
+}
+
+// CopyTo writes the histogram to the data point.
+func (h *Histogram) CopyTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetScale(h.scale)
+	dp.SetCount(h.count)
+	dp.SetSum(h.sum)
+	dp.SetZeroCount(h.zeroCount)
+	if h.count > 0 {
+		dp.SetMin(h.min)
+		dp.SetMax(h.max)
+	}
+	h.positive.copyTo(dp.Positive())
+	h.negative.copyTo(dp.Negative())
+}
+
+// mapToIndex returns the index of the bucket holding v at the given scale.
+// The bucket of index i holds the values in (base^i, base^(i+1)], where
+// base = 2^(2^-scale).
+func mapToIndex(v float64, scale int32) int32 {
+	// v = frac * 2^exp, with frac in [0.5, 1).
+	frac, exp := math.Frexp(v)
+	if scale <= 0 {
+		if frac == 0.5 {
+			// Exact powers of two are the upper boundary of their bucket.
+			exp--
+		}
+		return int32(exp-1) >> -scale
+	}
+	if frac == 0.5 {
+		return int32(exp-1)<<scale - 1
+	}
+	return int32(math.Ceil(math.Log(v)*math.Ldexp(math.Log2E, int(scale)))) - 1
+}
+
+// buckets holds the counts of contiguous buckets, counts[i] being the count
+// of the bucket of index offset+i.
+type buckets struct {
+	offset int32
+	counts []uint64
+}
+
+// scaleChange returns by how much the scale has to be reduced for the bucket
+// of the given index to fit along with the existing buckets.
+func (b *buckets) scaleChange(index int32, maxSize int32) int32 {
+	if len(b.counts) == 0 {
+		return 0
+	}
+	low, high := b.offset, b.offset+int32(len(b.counts))-1
+	if index < low {
+		low = index
+	} else if index > high {
+		high = index
+	}
+
+	var change int32
+	for high-low >= maxSize {
+		low >>= 1
+		high >>= 1
+		change++
+	}
+	return change
+}
+
+func (b *buckets) downscale(change int32) {
+	if len(b.counts) == 0 {
+		return
+	}
+	offset := b.offset >> change
+	high := (b.offset + int32(len(b.counts)) - 1) >> change
+	counts := make([]uint64, high-offset+1)
+	for i, c := range b.counts {
+		counts[(b.offset+int32(i))>>change-offset] += c
+	}
+	b.offset = offset
+	b.counts = counts
+}
+
+func (b *buckets) increment(index int32, incr uint64) {
+	switch {
+	case len(b.counts) == 0:
+		b.offset = index
+		b.counts = []uint64{0}
+	case index < b.offset:
+		counts := make([]uint64, b.offset-index+int32(len(b.counts)))
+		copy(counts[b.offset-index:], b.counts)
+		b.offset = index
+		b.counts = counts
+	case index >= b.offset+int32(len(b.counts)):
+		b.counts = append(b.counts, make([]uint64, index-b.offset-int32(len(b.counts))+1)...)
+	}
+	b.counts[index-b.offset] += incr
+}
+
+func (b *buckets) copyTo(dest pmetric.Buckets) {
+	dest.SetOffset(b.offset)
+	dest.BucketCounts().FromRaw(b.counts)
+}
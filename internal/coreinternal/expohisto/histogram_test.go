@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expohisto
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMapToIndex(t *testing.T) {
+	tests := []struct {
+		value    float64
+		scale    int32
+		expected int32
+	}{
+		{value: 1, scale: 0, expected: -1},
+		{value: 1.5, scale: 0, expected: 0},
+		{value: 2, scale: 0, expected: 0},
+		{value: 3, scale: 0, expected: 1},
+		{value: 0.5, scale: 0, expected: -2},
+		{value: 1, scale: -1, expected: -1},
+		{value: 4, scale: -1, expected: 0},
+		{value: 5, scale: -1, expected: 1},
+		{value: 1, scale: 1, expected: -1},
+		{value: 1.2, scale: 1, expected: 0},
+		{value: 2, scale: 1, expected: 1},
+		{value: 3, scale: 1, expected: 3},
+		{value: 2, scale: 3, expected: 7},
+		{value: math.MaxFloat64, scale: -10, expected: 0},
+		{value: math.SmallestNonzeroFloat64, scale: -10, expected: -2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, mapToIndex(tt.value, tt.scale), "value %v at scale %d", tt.value, tt.scale)
+	}
+}
+
+func TestMapToIndexBoundaries(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, scale := range []int32{-3, 0, 3, 8, MaxScale} {
+		base := math.Exp2(math.Exp2(-float64(scale)))
+		for i := 0; i < 1000; i++ {
+			v := math.Exp(r.Float64()*40 - 20)
+			index := mapToIndex(v, scale)
+			lower := math.Pow(base, float64(index))
+			upper := math.Pow(base, float64(index+1))
+			// Allow for the rounding of the boundaries themselves.
+			assert.True(t, lower <= v*(1+1e-9) && v <= upper*(1+1e-9),
+				"value %v at scale %d is not in (%v, %v]", v, scale, lower, upper)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	h := New(DefaultMaxSize, MaxScale)
+	for _, v := range []float64{-1, 0, 0, 1, 2, math.NaN(), math.Inf(1)} {
+		h.Update(v)
+	}
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, float64(2), dp.Sum())
+	assert.Equal(t, float64(-1), dp.Min())
+	assert.Equal(t, float64(2), dp.Max())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, uint64(2), sum(dp.Positive().BucketCounts().AsRaw()))
+	assert.Equal(t, uint64(1), sum(dp.Negative().BucketCounts().AsRaw()))
+	assert.Equal(t, h.Scale(), dp.Scale())
+}
+
+func TestUpdateByIncr(t *testing.T) {
+	h := New(DefaultMaxSize, MaxScale)
+	h.UpdateByIncr(-1, 2)
+	h.UpdateByIncr(0, 3)
+	h.UpdateByIncr(4, 10)
+	h.UpdateByIncr(5, 0)
+	h.UpdateByIncr(math.NaN(), 1)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.Equal(t, uint64(15), dp.Count())
+	assert.Equal(t, float64(38), dp.Sum())
+	assert.Equal(t, float64(-1), dp.Min())
+	assert.Equal(t, float64(4), dp.Max())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	assert.Equal(t, uint64(10), sum(dp.Positive().BucketCounts().AsRaw()))
+	assert.Equal(t, uint64(2), sum(dp.Negative().BucketCounts().AsRaw()))
+}
+
+func TestDownscale(t *testing.T) {
+	h := New(4, MaxScale)
+	h.Update(1)
+	assert.Equal(t, int32(MaxScale), h.Scale())
+
+	// 1, 2, 4, 8 and 16 fit into 4 buckets at scale -1: (0.25, 1], (1, 4],
+	// (4, 16], the last one holding both 8 and 16.
+	for _, v := range []float64{2, 4, 8, 16} {
+		h.Update(v)
+	}
+	assert.Equal(t, int32(-1), h.Scale())
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2, 2}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestDownscaleKeepsBothSignsAtTheSameScale(t *testing.T) {
+	h := New(MinSize, MaxScale)
+	h.Update(-1)
+	h.Update(1)
+	h.Update(1e300)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.GreaterOrEqual(t, dp.Scale(), int32(MinScale))
+	require.LessOrEqual(t, dp.Positive().BucketCounts().Len(), MinSize)
+	assert.Equal(t, uint64(2), sum(dp.Positive().BucketCounts().AsRaw()))
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+	assert.Equal(t, mapToIndex(1, dp.Scale()), dp.Negative().Offset())
+}
+
+func TestBucketsFitMaxSize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := New(20, MaxScale)
+	for i := 0; i < 10000; i++ {
+		h.Update(math.Exp(r.NormFloat64() * 10))
+	}
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	h.CopyTo(dp)
+	assert.LessOrEqual(t, dp.Positive().BucketCounts().Len(), 20)
+	assert.Equal(t, uint64(10000), sum(dp.Positive().BucketCounts().AsRaw()))
+	assert.Equal(t, uint64(10000), h.Count())
+}
+
+func sum(counts []uint64) uint64 {
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
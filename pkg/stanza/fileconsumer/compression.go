@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// compressionNone reads files as they are.
+	compressionNone = ""
+	// compressionAuto detects the compression of each file from its first bytes,
+	// files that are not compressed are read as they are.
+	compressionAuto = "auto"
+	// compressionGzip reads every file as a stream of gzip members.
+	compressionGzip = "gzip"
+	// compressionZstd reads every file as a stream of zstd frames.
+	compressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionAuto, compressionGzip, compressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// detectCompression returns the compression of the file, according to the
+// configured compression.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != compressionAuto {
+		return compression, nil
+	}
+
+	buf := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return compressionNone, fmt.Errorf("reading magic bytes: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(buf[:n], gzipMagic):
+		return compressionGzip, nil
+	case bytes.HasPrefix(buf[:n], zstdMagic):
+		return compressionZstd, nil
+	default:
+		return compressionNone, nil
+	}
+}
+
+// decompressor reads the decompressed content of a file.
+type decompressor struct {
+	io.Reader
+	close func()
+	// read is the number of decompressed bytes read so far
+	read int64
+}
+
+// newDecompressor returns a decompressor reading the file from its beginning.
+// A member or frame that was not completely written yet is reported as the
+// end of the file, so it is read again once it is complete.
+func newDecompressor(file io.ReaderAt, compression string) (*decompressor, error) {
+	src := io.NewSectionReader(file, 0, 1<<63-1)
+	switch compression {
+	case compressionGzip:
+		gz, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressor{Reader: truncatedAsEOF{gz}, close: func() { _ = gz.Close() }}, nil
+	case compressionZstd:
+		zr, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &decompressor{Reader: truncatedAsEOF{zr}, close: zr.Close}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+func (d *decompressor) Read(p []byte) (int, error) {
+	n, err := d.Reader.Read(p)
+	d.read += int64(n)
+	return n, err
+}
+
+func (d *decompressor) Close() {
+	d.close()
+}
+
+// truncatedAsEOF reports an unexpected end of the compressed stream as the end
+// of the file.
+type truncatedAsEOF struct {
+	io.Reader
+}
+
+func (r truncatedAsEOF) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// newCompressedFingerprint creates a fingerprint from the decompressed content
+// of a file, so that a file keeps its fingerprint once it is compressed.
+func newCompressedFingerprint(file *os.File, compression string, size int) (*Fingerprint, error) {
+	d, err := newDecompressor(file, compression)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// the header was not completely written yet
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	defer d.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(d, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeBytes(t *testing.T, file *os.File, b []byte) {
+	_, err := file.Write(b)
+	require.NoError(t, err)
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		compression string
+		compress    func(*testing.T, string) []byte
+	}{
+		{"AutoGzip", compressionAuto, gzipBytes},
+		{"AutoZstd", compressionAuto, zstdBytes},
+		{"Gzip", compressionGzip, gzipBytes},
+		{"Zstd", compressionZstd, zstdBytes},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			writeBytes(t, temp, tc.compress(t, "testlog1\ntestlog2\n"))
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			waitForToken(t, emitCalls, []byte("testlog2"))
+		})
+	}
+}
+
+func TestAutoCompressionReadsPlainFiles(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	plain := openTemp(t, tempDir)
+	writeString(t, plain, "plain\n")
+	compressed := openTemp(t, tempDir)
+	writeBytes(t, compressed, gzipBytes(t, "compressed\n"))
+
+	operator.persister = testutil.NewMockPersister("test")
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("plain"), []byte("compressed")})
+}
+
+// TestGzipAppendedMembers tests that a file that is appended to as a series of
+// gzip members is read incrementally.
+func TestGzipAppendedMembers(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeBytes(t, temp, gzipBytes(t, "testlog1\ntestlog2\n"))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// Write the next member in two parts, the first part must not be read yet
+	member := gzipBytes(t, "testlog3\n")
+	writeBytes(t, temp, member[:len(member)/2])
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	writeBytes(t, temp, member[len(member)/2:])
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestCompressedRotation tests that a file that is compressed when it is rotated
+// is recognized by its fingerprint, and only the lines that were not read before
+// the rotation are read from the compressed file.
+func TestCompressedRotation(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	logPath := filepath.Join(tempDir, "app.log")
+	temp := openFile(t, logPath)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// Lines written just before the rotation
+	writeString(t, temp, "testlog3\n")
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	require.NoError(t, temp.Close())
+	require.NoError(t, os.Remove(logPath))
+	archive := openFile(t, logPath+".1.gz")
+	writeBytes(t, archive, gzipBytes(t, string(content)))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedStartAtEnd(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeBytes(t, temp, gzipBytes(t, "testlog1\n"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	writeBytes(t, temp, gzipBytes(t, "testlog2\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
+
+func TestCompressedFingerprint(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+
+	plain := openTemp(t, tempDir)
+	writeString(t, plain, "testlog1\ntestlog2\n")
+	compressed := openTemp(t, tempDir)
+	writeBytes(t, compressed, gzipBytes(t, "testlog1\ntestlog2\n"))
+
+	plainFp, err := NewFingerprint(plain, 16)
+	require.NoError(t, err)
+	compressedFp, err := newCompressedFingerprint(compressed, compressionGzip, 16)
+	require.NoError(t, err)
+	require.Equal(t, plainFp, compressedFp)
+
+	// The header of the file is not completely written yet
+	partial := openTemp(t, tempDir)
+	writeBytes(t, partial, gzipMagic)
+	partialFp, err := newCompressedFingerprint(partial, compressionGzip, 16)
+	require.NoError(t, err)
+	require.Empty(t, partialFp.FirstBytes)
+}
+
+// TestUnchangedCompressedFile tests that a compressed file that was read to its
+// end is not decompressed again until it changes.
+func TestUnchangedCompressedFile(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	content := gzipBytes(t, "testlog1\ntestlog2\n")
+	writeBytes(t, temp, content)
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	known := operator.knownFiles[len(operator.knownFiles)-1]
+	require.Equal(t, int64(len(content)), known.CompressedSize)
+
+	file, err := os.Open(temp.Name())
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := operator.readerFactory.copy(known, file)
+	require.NoError(t, err)
+	ok, _, _, err := reader.seekToOffset()
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, reader.source, "the unchanged file must not be decompressed")
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	writeBytes(t, temp, gzipBytes(t, "testlog3\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestCompressedFileNoNewline tests that the last line of a compressed file is
+// still flushed when the file does not change anymore.
+func TestCompressedFileNoNewline(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	cfg.Splitter = helper.NewSplitterConfig()
+	cfg.Splitter.Flusher.Period = 100 * time.Millisecond
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeBytes(t, temp, gzipBytes(t, "testlog1\ntestlog2"))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	expectNoTokens(t, emitCalls)
+
+	time.Sleep(2 * cfg.Splitter.Flusher.Period)
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
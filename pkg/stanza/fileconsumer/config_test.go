@@ -320,6 +320,35 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "compression_auto",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Compression = "auto"
+				return cfg
+			}(),
+		},
+		{
+			Name:      "ordering_criteria",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.OrderingCriteria = OrderingCriteria{
+					Regex: `app-(?P<date>\d{8})\.log`,
+					TopN:  3,
+					SortBy: []SortRule{
+						{
+							SortType: "timestamp",
+							RegexKey: "date",
+							Layout:   "%Y%m%d",
+							Location: "UTC",
+						},
+					},
+				}
+				return cfg
+			}(),
+		},
 		{
 			Name:      "max_concurrent_large",
 			ExpectErr: false,
@@ -468,6 +497,47 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"CompressionGzip",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "gzip", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"OrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `app\.(?P<rotation>\d+)\.log`,
+					SortBy: []SortRule{{SortType: "numeric", RegexKey: "rotation"}},
+				}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.NotNil(t, f.ordering)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					SortBy: []SortRule{{SortType: "size"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
@@ -37,6 +37,7 @@ type Manager struct {
 
 	readerFactory readerFactory
 	finder        Finder
+	ordering      *fileOrdering
 	roller        roller
 	persister     operator.Persister
 
@@ -45,6 +46,9 @@ type Manager struct {
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
+	// unorderedFiles are the files the ordering criteria left out in the last
+	// poll, so that they are only reported once.
+	unorderedFiles map[string]error
 }
 
 func (m *Manager) Start(persister operator.Persister) error {
@@ -112,7 +116,8 @@ func (m *Manager) poll(ctx context.Context) {
 	}
 
 	// Get the list of paths on disk
-	matches := m.finder.FindFiles()
+	matches, unordered := m.ordering.apply(m.finder.FindFiles())
+	m.reportUnorderedFiles(unordered)
 	for len(matches) > m.maxBatchFiles {
 		m.consume(ctx, matches[:m.maxBatchFiles])
 		matches = matches[m.maxBatchFiles:]
@@ -120,6 +125,17 @@ func (m *Manager) poll(ctx context.Context) {
 	m.consume(ctx, matches)
 }
 
+// reportUnorderedFiles warns about the files that the ordering criteria leave
+// out, once for as long as they keep being left out.
+func (m *Manager) reportUnorderedFiles(unordered map[string]error) {
+	for path, err := range unordered {
+		if _, ok := m.unorderedFiles[path]; !ok {
+			m.Warnw("Skipping file which could not be ordered", "path", path, zap.Error(err))
+		}
+	}
+	m.unorderedFiles = unordered
+}
+
 func (m *Manager) consume(ctx context.Context, paths []string) {
 	m.Debug("Consuming files")
 	readers := m.makeReaders(paths)
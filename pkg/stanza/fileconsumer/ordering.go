@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+	sortTypeMtime        = "mtime"
+)
+
+// OrderingCriteria configures the order in which the matched files are consumed,
+// and optionally restricts them to the first ones in that order.
+type OrderingCriteria struct {
+	// Regex is matched against the path of each file. The values sorted on are
+	// taken from its named capture groups.
+	Regex string `mapstructure:"regex,omitempty" json:"regex,omitempty" yaml:"regex,omitempty"`
+	// TopN is the number of files kept after sorting, 0 keeps all of them.
+	TopN   int        `mapstructure:"top_n,omitempty" json:"top_n,omitempty" yaml:"top_n,omitempty"`
+	SortBy []SortRule `mapstructure:"sort_by,omitempty" json:"sort_by,omitempty" yaml:"sort_by,omitempty"`
+}
+
+// SortRule is a single rule files are sorted by. Files are sorted by the first
+// rule, later rules only order the files the previous rules consider equal.
+type SortRule struct {
+	SortType  string `mapstructure:"sort_type,omitempty" json:"sort_type,omitempty" yaml:"sort_type,omitempty"`
+	RegexKey  string `mapstructure:"regex_key,omitempty" json:"regex_key,omitempty" yaml:"regex_key,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty" json:"ascending,omitempty" yaml:"ascending,omitempty"`
+	// Layout is the strptime layout of timestamp captures.
+	Layout string `mapstructure:"layout,omitempty" json:"layout,omitempty" yaml:"layout,omitempty"`
+	// Location is the IANA time zone of timestamp captures, defaults to the local time zone.
+	Location string `mapstructure:"location,omitempty" json:"location,omitempty" yaml:"location,omitempty"`
+}
+
+// fileOrdering is the compiled form of OrderingCriteria.
+type fileOrdering struct {
+	regex *regexp.Regexp
+	topN  int
+	rules []sortRule
+}
+
+type sortRule struct {
+	sortType  string
+	group     int
+	ascending bool
+	layout    string
+	location  *time.Location
+}
+
+// sortValue is the value of a file for one rule, only the field matching the
+// sort type of the rule is set.
+type sortValue struct {
+	num int64
+	str string
+	ts  time.Time
+}
+
+func (c OrderingCriteria) build() (*fileOrdering, error) {
+	if len(c.SortBy) == 0 {
+		if c.TopN != 0 {
+			return nil, fmt.Errorf("`top_n` requires `sort_by` to be set")
+		}
+		if c.Regex != "" {
+			return nil, fmt.Errorf("`regex` requires `sort_by` to be set")
+		}
+		return nil, nil
+	}
+	if c.TopN < 0 {
+		return nil, fmt.Errorf("`top_n` must not be negative")
+	}
+
+	o := &fileOrdering{topN: c.TopN}
+	if c.Regex != "" {
+		regex, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("compiling regex: %w", err)
+		}
+		o.regex = regex
+	}
+
+	for _, rule := range c.SortBy {
+		r := sortRule{sortType: rule.SortType, ascending: rule.Ascending}
+		switch rule.SortType {
+		case sortTypeMtime:
+			o.rules = append(o.rules, r)
+			continue
+		case sortTypeNumeric, sortTypeAlphabetical:
+		case sortTypeTimestamp:
+			if rule.Layout == "" {
+				return nil, fmt.Errorf("`layout` is required for sort type '%s'", rule.SortType)
+			}
+			layout, err := strptime.ToNative(rule.Layout)
+			if err != nil {
+				return nil, fmt.Errorf("parse strptime layout: %w", err)
+			}
+			r.layout = layout
+			r.location = time.Local
+			if rule.Location != "" {
+				if r.location, err = time.LoadLocation(rule.Location); err != nil {
+					return nil, fmt.Errorf("failed to load location %s: %w", rule.Location, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid sort type '%s'", rule.SortType)
+		}
+
+		if o.regex == nil {
+			return nil, fmt.Errorf("`regex` is required for sort type '%s'", rule.SortType)
+		}
+		r.group = o.regex.SubexpIndex(rule.RegexKey)
+		if r.group < 0 {
+			return nil, fmt.Errorf("`regex` has no capture group named '%s'", rule.RegexKey)
+		}
+		o.rules = append(o.rules, r)
+	}
+	return o, nil
+}
+
+// apply sorts the paths and keeps the first top_n of them. Paths that the
+// regex does not match, or whose values cannot be parsed, are left out and
+// returned with the reason.
+func (o *fileOrdering) apply(paths []string) ([]string, map[string]error) {
+	if o == nil {
+		return paths, nil
+	}
+
+	type orderedFile struct {
+		path   string
+		values []sortValue
+	}
+	var skipped map[string]error
+	skip := func(path string, err error) {
+		if skipped == nil {
+			skipped = make(map[string]error)
+		}
+		skipped[path] = err
+	}
+	files := make([]orderedFile, 0, len(paths))
+PATHS:
+	for _, path := range paths {
+		var submatches []string
+		if o.regex != nil {
+			if submatches = o.regex.FindStringSubmatch(path); submatches == nil {
+				skip(path, errors.New("the file does not match the ordering regex"))
+				continue
+			}
+		}
+		values := make([]sortValue, len(o.rules))
+		for i, rule := range o.rules {
+			v, err := rule.value(path, submatches)
+			if err != nil {
+				skip(path, err)
+				continue PATHS
+			}
+			values[i] = v
+		}
+		files = append(files, orderedFile{path: path, values: values})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		for k, rule := range o.rules {
+			if c := rule.compare(files[i].values[k], files[j].values[k]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	if o.topN > 0 && len(files) > o.topN {
+		files = files[:o.topN]
+	}
+
+	result := make([]string, 0, len(files))
+	for _, f := range files {
+		result = append(result, f.path)
+	}
+	return result, skipped
+}
+
+func (r sortRule) value(path string, submatches []string) (sortValue, error) {
+	switch r.sortType {
+	case sortTypeMtime:
+		info, err := os.Stat(path)
+		if err != nil {
+			return sortValue{}, err
+		}
+		return sortValue{ts: info.ModTime()}, nil
+	case sortTypeNumeric:
+		num, err := strconv.ParseInt(submatches[r.group], 10, 64)
+		if err != nil {
+			return sortValue{}, fmt.Errorf("parse numeric value: %w", err)
+		}
+		return sortValue{num: num}, nil
+	case sortTypeTimestamp:
+		ts, err := time.ParseInLocation(r.layout, submatches[r.group], r.location)
+		if err != nil {
+			return sortValue{}, fmt.Errorf("parse timestamp value: %w", err)
+		}
+		return sortValue{ts: ts}, nil
+	default:
+		return sortValue{str: submatches[r.group]}, nil
+	}
+}
+
+// compare returns a negative number if a comes first, a positive number if b
+// comes first and 0 if they are equal. Values are in descending order unless
+// the rule is ascending.
+func (r sortRule) compare(a, b sortValue) int {
+	var c int
+	switch r.sortType {
+	case sortTypeMtime, sortTypeTimestamp:
+		switch {
+		case a.ts.Before(b.ts):
+			c = -1
+		case a.ts.After(b.ts):
+			c = 1
+		}
+	case sortTypeNumeric:
+		switch {
+		case a.num < b.num:
+			c = -1
+		case a.num > b.num:
+			c = 1
+		}
+	default:
+		switch {
+		case a.str < b.str:
+			c = -1
+		case a.str > b.str:
+			c = 1
+		}
+	}
+	if !r.ascending {
+		c = -c
+	}
+	return c
+}
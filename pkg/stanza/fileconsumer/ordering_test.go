@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestOrderingCriteriaBuild(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		criteria    OrderingCriteria
+		expectedErr string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Numeric",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "rotation"}},
+			},
+		},
+		{
+			name: "Mtime",
+			criteria: OrderingCriteria{
+				TopN:   2,
+				SortBy: []SortRule{{SortType: "mtime"}},
+			},
+		},
+		{
+			name:        "TopNWithoutSortBy",
+			criteria:    OrderingCriteria{TopN: 1},
+			expectedErr: "`top_n` requires `sort_by` to be set",
+		},
+		{
+			name:        "RegexWithoutSortBy",
+			criteria:    OrderingCriteria{Regex: `.*`},
+			expectedErr: "`regex` requires `sort_by` to be set",
+		},
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				TopN:   -1,
+				SortBy: []SortRule{{SortType: "mtime"}},
+			},
+			expectedErr: "`top_n` must not be negative",
+		},
+		{
+			name: "InvalidRegex",
+			criteria: OrderingCriteria{
+				Regex:  `(`,
+				SortBy: []SortRule{{SortType: "mtime"}},
+			},
+			expectedErr: "compiling regex",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: "size"}},
+			},
+			expectedErr: "invalid sort type 'size'",
+		},
+		{
+			name: "MissingRegex",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: "alphabetical", RegexKey: "name"}},
+			},
+			expectedErr: "`regex` is required for sort type 'alphabetical'",
+		},
+		{
+			name: "UnknownRegexKey",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<name>\w+)\.log`,
+				SortBy: []SortRule{{SortType: "alphabetical", RegexKey: "other"}},
+			},
+			expectedErr: "`regex` has no capture group named 'other'",
+		},
+		{
+			name: "MissingLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date"}},
+			},
+			expectedErr: "`layout` is required for sort type 'timestamp'",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date", Layout: "%Y%m%d", Location: "Nowhere/Nothing"}},
+			},
+			expectedErr: "failed to load location Nowhere/Nothing",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tc.criteria.build()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrderingApply(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		files    []string
+		expected []string
+		skipped  []string
+	}{
+		{
+			name: "NumericDescending",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "rotation"}},
+			},
+			files:    []string{"app.1.log", "app.10.log", "app.2.log"},
+			expected: []string{"app.10.log", "app.2.log", "app.1.log"},
+		},
+		{
+			name: "NumericAscending",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "rotation", Ascending: true}},
+			},
+			files:    []string{"app.1.log", "app.10.log", "app.2.log"},
+			expected: []string{"app.1.log", "app.2.log", "app.10.log"},
+		},
+		{
+			name: "Alphabetical",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<name>[a-z]+)\.log`,
+				SortBy: []SortRule{{SortType: "alphabetical", RegexKey: "name", Ascending: true}},
+			},
+			files:    []string{"c.log", "a.log", "b.log"},
+			expected: []string{"a.log", "b.log", "c.log"},
+		},
+		{
+			name: "TimestampTopN",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<date>\d{4}-\d{2}-\d{2})\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date", Layout: "%Y-%m-%d", Location: "UTC"}},
+			},
+			files:    []string{"app-2022-09-30.log", "app-2022-10-02.log", "app-2022-10-01.log"},
+			expected: []string{"app-2022-10-02.log", "app-2022-10-01.log"},
+		},
+		{
+			name: "SecondRuleBreaksTies",
+			criteria: OrderingCriteria{
+				Regex: `(?P<host>[a-z]+)-(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{
+					{SortType: "timestamp", RegexKey: "date", Layout: "%Y%m%d"},
+					{SortType: "alphabetical", RegexKey: "host", Ascending: true},
+				},
+			},
+			files:    []string{"b-20221001.log", "a-20221001.log", "c-20221002.log"},
+			expected: []string{"c-20221002.log", "a-20221001.log", "b-20221001.log"},
+		},
+		{
+			name: "UnmatchedFilesAreSkipped",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "rotation"}},
+			},
+			files:    []string{"app.1.log", "other.log", "app.2.log"},
+			expected: []string{"app.2.log", "app.1.log"},
+			skipped:  []string{"other.log"},
+		},
+		{
+			name: "UnparsableFilesAreSkipped",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<date>\d{4}-\d{2}-\d{2})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date", Layout: "%Y-%m-%d"}},
+			},
+			files:    []string{"app-2022-10-01.log", "app-2022-13-01.log"},
+			expected: []string{"app-2022-10-01.log"},
+			skipped:  []string{"app-2022-13-01.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ordering, err := tc.criteria.build()
+			require.NoError(t, err)
+
+			files, skipped := ordering.apply(tc.files)
+			require.Equal(t, tc.expected, files)
+			require.Len(t, skipped, len(tc.skipped))
+			for _, path := range tc.skipped {
+				require.Error(t, skipped[path])
+			}
+		})
+	}
+}
+
+func TestOrderingMtime(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	now := time.Now()
+	files := []string{"old.log", "newest.log", "new.log"}
+	for i, age := range []time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour} {
+		path := filepath.Join(tempDir, files[i])
+		require.NoError(t, os.WriteFile(path, []byte(files[i]), 0600))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+
+	ordering, err := OrderingCriteria{
+		TopN:   2,
+		SortBy: []SortRule{{SortType: "mtime"}},
+	}.build()
+	require.NoError(t, err)
+
+	ordered, skipped := ordering.apply(absPath(tempDir, files))
+	require.Empty(t, skipped)
+	require.Equal(t, absPath(tempDir, []string{"newest.log", "new.log"}), ordered)
+}
+
+// TestReadNewestFilesOnly checks that files which are not among the newest
+// ones are not read.
+func TestReadNewestFilesOnly(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.OrderingCriteria = OrderingCriteria{
+		Regex:  `app-(?P<date>\d{4}-\d{2}-\d{2})\.log`,
+		TopN:   1,
+		SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date", Layout: "%Y-%m-%d"}},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	oldFile := openFile(t, filepath.Join(tempDir, "app-2022-09-30.log"))
+	writeString(t, oldFile, "old log\n")
+	newFile := openFile(t, filepath.Join(tempDir, "app-2022-10-01.log"))
+	writeString(t, newFile, "new log\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("new log"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestUnorderedFilesAreReported checks that files which the ordering regex
+// does not match are not read, and are reported once as long as they do not
+// match.
+func TestUnorderedFilesAreReported(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.OrderingCriteria = OrderingCriteria{
+		Regex:  `app-(?P<date>\d{4}-\d{2}-\d{2})\.log`,
+		SortBy: []SortRule{{SortType: "timestamp", RegexKey: "date", Layout: "%Y-%m-%d"}},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	core, observedLogs := observer.New(zap.WarnLevel)
+	operator.SugaredLogger = zap.New(core).Sugar()
+
+	appFile := openFile(t, filepath.Join(tempDir, "app-2022-10-01.log"))
+	writeString(t, appFile, "app log\n")
+	otherPath := filepath.Join(tempDir, "other.log")
+	otherFile := openFile(t, otherPath)
+	writeString(t, otherFile, "other log\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("app log"))
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	logs := observedLogs.FilterMessage("Skipping file which could not be ordered").All()
+	require.Len(t, logs, 1)
+	require.Equal(t, otherPath, logs[0].ContextMap()["path"])
+}
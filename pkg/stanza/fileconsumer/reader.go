@@ -16,7 +16,9 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -27,6 +29,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
 	emit            EmitFunc
 }
 
@@ -41,10 +44,37 @@ type Reader struct {
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+	// compression of the file, offsets of compressed files refer to the decompressed content
+	compression string
+	// CompressedSize is the size of a compressed file when it was last read to
+	// its end. The file is not decompressed again until its size changes.
+	CompressedSize int64
+	// source is the file, or its decompressed content, positioned at the offset
+	source io.Reader
 }
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.compression != compressionNone {
+		info, err := r.file.Stat()
+		if err != nil {
+			return fmt.Errorf("stat: %w", err)
+		}
+		d, err := newDecompressor(r.file, r.compression)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			r.Offset = 0
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		defer d.Close()
+		if r.Offset, err = io.Copy(io.Discard, d); err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.CompressedSize = info.Size()
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -53,12 +83,51 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
+// seekToOffset positions the source of the reader at the offset. Compressed
+// files are decompressed from their beginning, since they cannot be seeked,
+// unless their size did not change since they were last read to their end.
+// It returns false if there is nothing to read yet, and the size of a
+// compressed file otherwise.
+func (r *Reader) seekToOffset() (bool, int64, func(), error) {
+	if r.compression == compressionNone {
+		r.source = r.file
+		_, err := r.file.Seek(r.Offset, 0)
+		return err == nil, 0, func() {}, err
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if info.Size() == r.CompressedSize {
+		return false, 0, nil, nil
+	}
+
+	d, err := newDecompressor(r.file, r.compression)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, 0, nil, nil
+	} else if err != nil {
+		return false, 0, nil, err
+	}
+	if _, err = io.CopyN(io.Discard, d, r.Offset); err != nil {
+		d.Close()
+		return false, 0, nil, err
+	}
+	r.source = d
+	return true, info.Size(), d.Close, nil
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	ok, compressedSize, closeSource, err := r.seekToOffset()
+	if err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	if !ok {
+		return
+	}
+	defer closeSource()
 
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
 
@@ -74,6 +143,12 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := scanner.getError(); err != nil {
 				r.Errorw("Failed during scan", zap.Error(err))
+				return
+			}
+			// Content left unread, such as a last line waiting to be flushed,
+			// requires the file to be decompressed again
+			if d, ok := r.source.(*decompressor); ok && d.read == r.Offset {
+				r.CompressedSize = compressedSize
 			}
 			break
 		}
@@ -103,9 +178,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.source.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.source.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
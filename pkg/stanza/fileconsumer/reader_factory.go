@@ -42,6 +42,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withCompressedSize(old.CompressedSize).
 		withSplitter(old.splitter).
 		build()
 }
@@ -50,16 +51,26 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 	return f.newReaderBuilder().build()
 }
 
+// newFingerprint creates the fingerprint of the file. The fingerprint of a
+// compressed file is made of its decompressed content.
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression, err := detectCompression(file, f.readerConfig.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression != compressionNone {
+		return newCompressedFingerprint(file, compression, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
 	*readerFactory
-	file     *os.File
-	fp       *Fingerprint
-	offset   int64
-	splitter *helper.Splitter
+	file           *os.File
+	fp             *Fingerprint
+	offset         int64
+	compressedSize int64
+	splitter       *helper.Splitter
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -86,10 +97,16 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(size int64) *readerBuilder {
+	b.compressedSize = size
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:   b.readerConfig,
+		Offset:         b.offset,
+		CompressedSize: b.compressedSize,
 	}
 
 	if b.splitter != nil {
@@ -108,6 +125,10 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		r.compression, err = detectCompression(b.file, b.readerConfig.compression)
+		if err != nil {
+			return nil, err
+		}
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
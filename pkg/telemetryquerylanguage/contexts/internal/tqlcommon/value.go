@@ -85,6 +85,10 @@ func SetValue(value pcommon.Value, val interface{}) {
 		for _, b := range v {
 			value.SliceVal().AppendEmpty().SetEmptyBytesVal().FromRaw(b)
 		}
+	case []interface{}:
+		value.SetEmptySliceVal().FromRaw(v)
+	case map[string]interface{}:
+		value.SetEmptyMapVal().FromRaw(v)
 	default:
 		// TODO(anuraaga): Support set of map type.
 	}
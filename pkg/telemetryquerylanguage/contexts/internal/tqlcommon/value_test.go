@@ -0,0 +1,75 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		val      interface{}
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			val:      "str",
+			expected: "str",
+		},
+		{
+			name:     "string slice",
+			val:      []string{"a", "b"},
+			expected: []interface{}{"a", "b"},
+		},
+		{
+			name:     "list",
+			val:      []interface{}{"a", int64(1), 1.5, true, []interface{}{"b"}},
+			expected: []interface{}{"a", int64(1), 1.5, true, []interface{}{"b"}},
+		},
+		{
+			name: "map",
+			val: map[string]interface{}{
+				"a": "b",
+				"c": map[string]interface{}{"d": int64(1)},
+			},
+			expected: map[string]interface{}{
+				"a": "b",
+				"c": map[string]interface{}{"d": int64(1)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := pcommon.NewValueSlice()
+			SetValue(value, tt.val)
+			assert.Equal(t, tt.expected, value.AsRaw())
+		})
+	}
+}
+
+func TestSetMapValue(t *testing.T) {
+	attrs := pcommon.NewMap()
+	SetMapValue(attrs, "list", []interface{}{"a", int64(1)})
+	SetMapValue(attrs, "map", map[string]interface{}{"a": "b"})
+
+	assert.Equal(t, map[string]interface{}{
+		"list": []interface{}{"a", int64(1)},
+		"map":  map[string]interface{}{"a": "b"},
+	}, attrs.AsRaw())
+}
@@ -0,0 +1,104 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlmetric // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetric"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type transformContext struct {
+	metric               pmetric.Metric
+	metrics              pmetric.MetricSlice
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+}
+
+func NewTransformContext(metric pmetric.Metric, metrics pmetric.MetricSlice, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) tql.TransformContext {
+	return transformContext{
+		metric:               metric,
+		metrics:              metrics,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+	}
+}
+
+func (ctx transformContext) GetItem() interface{} {
+	return ctx.metric
+}
+
+func (ctx transformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.instrumentationScope
+}
+
+func (ctx transformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// ParseEnum supports the same enums as the Metrics Context.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	return tqlmetrics.ParseEnum(val)
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		return tqlcommon.ResourcePathGetSetter(path[1:])
+	case "instrumentation_scope":
+		return tqlcommon.ScopePathGetSetter(path[1:])
+	case "name", "description", "unit", "type", "aggregation_temporality", "is_monotonic":
+		if len(path) > 1 {
+			return nil, fmt.Errorf("invalid path expression %v", path)
+		}
+		return metricPathGetSetter(path)
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+}
+
+// metricPathGetSetter evaluates the path as a metric path of the Metrics
+// Context, so that both contexts access the metric fields the same way.
+func metricPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	getSetter, err := tqlmetrics.ParsePath(&tql.Path{Fields: append([]tql.Field{{Name: "metric"}}, path...)})
+	if err != nil {
+		return nil, err
+	}
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return getSetter.Get(metricsTransformContext(ctx))
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			getSetter.Set(metricsTransformContext(ctx), val)
+		},
+	}, nil
+}
+
+func metricsTransformContext(ctx tql.TransformContext) tql.TransformContext {
+	mCtx := ctx.(transformContext)
+	return tqlmetrics.NewTransformContext(nil, mCtx.metric, mCtx.metrics, mCtx.instrumentationScope, mCtx.resource)
+}
@@ -0,0 +1,238 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "requests",
+			newVal: "http.requests",
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.SetName("http.requests")
+			},
+		},
+		{
+			name: "description",
+			path: []tql.Field{
+				{
+					Name: "description",
+				},
+			},
+			orig:   "number of requests",
+			newVal: "number of HTTP requests",
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.SetDescription("number of HTTP requests")
+			},
+		},
+		{
+			name: "unit",
+			path: []tql.Field{
+				{
+					Name: "unit",
+				},
+			},
+			orig:   "1",
+			newVal: "{requests}",
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.SetUnit("{requests}")
+			},
+		},
+		{
+			name: "type",
+			path: []tql.Field{
+				{
+					Name: "type",
+				},
+			},
+			orig:   int64(pmetric.MetricDataTypeSum),
+			newVal: int64(pmetric.MetricDataTypeSum),
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+			},
+		},
+		{
+			name: "aggregation_temporality",
+			path: []tql.Field{
+				{
+					Name: "aggregation_temporality",
+				},
+			},
+			orig:   int64(pmetric.MetricAggregationTemporalityCumulative),
+			newVal: int64(pmetric.MetricAggregationTemporalityDelta),
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+			},
+		},
+		{
+			name: "is_monotonic",
+			path: []tql.Field{
+				{
+					Name: "is_monotonic",
+				},
+			},
+			orig:   true,
+			newVal: false,
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.Sum().SetIsMonotonic(false)
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutString("service.name", "cart")
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newLibrary",
+			modified: func(metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newLibrary")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			metric, il, resource := createTelemetry()
+			ctx := NewTransformContext(metric, pmetric.NewMetricSlice(), il, resource)
+
+			got := accessor.Get(ctx)
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(ctx, tt.newVal)
+
+			exMetric, exIl, exRes := createTelemetry()
+			tt.modified(exMetric, exIl, exRes)
+
+			assert.Equal(t, exMetric, metric)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "unknown",
+				},
+			},
+		},
+		{
+			name: "data point field",
+			path: []tql.Field{
+				{
+					Name: "value_double",
+				},
+			},
+		},
+		{
+			name: "nested metric field",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+				{
+					Name: "unknown",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (pmetric.Metric, pcommon.InstrumentationScope, pcommon.Resource) {
+	metric := pmetric.NewMetric()
+	metric.SetName("requests")
+	metric.SetDescription("number of requests")
+	metric.SetUnit("1")
+	sum := metric.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	sum.DataPoints().AppendEmpty().SetIntVal(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutString("service.name", "checkout")
+
+	return metric, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	enum, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp("AGGREGATION_TEMPORALITY_DELTA")))
+	assert.NoError(t, err)
+	assert.Equal(t, tql.Enum(pmetric.MetricAggregationTemporalityDelta), *enum)
+
+	_, err = ParseEnum((*tql.EnumSymbol)(tqltest.Strp("NOT_AN_ENUM")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ParsePath(t *testing.T) {
+	refResource := createResource()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(resource pcommon.Resource)
+	}{
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refResource.Attributes(),
+			newVal: newAttrs,
+			modified: func(resource pcommon.Resource) {
+				newAttrs.CopyTo(resource.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(resource pcommon.Resource) {
+				resource.Attributes().PutString("service.name", "cart")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(resource pcommon.Resource) {
+				resource.SetDroppedAttributesCount(20)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ParsePath(&tql.Path{Fields: tt.path})
+			assert.NoError(t, err)
+
+			resource := createResource()
+
+			got := accessor.Get(NewTransformContext(resource))
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(NewTransformContext(resource), tt.newVal)
+
+			exRes := createResource()
+			tt.modified(exRes)
+
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_ParsePath_invalid(t *testing.T) {
+	_, err := ParsePath(&tql.Path{Fields: []tql.Field{{Name: "name"}}})
+	assert.Error(t, err)
+
+	_, err = ParsePath(&tql.Path{})
+	assert.Error(t, err)
+}
+
+func Test_TransformContext(t *testing.T) {
+	resource := createResource()
+	ctx := NewTransformContext(resource)
+	assert.Equal(t, resource, ctx.GetItem())
+	assert.Equal(t, resource, ctx.GetResource())
+	assert.Equal(t, pcommon.NewInstrumentationScope(), ctx.GetInstrumentationScope())
+}
+
+func createResource() pcommon.Resource {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutString("service.name", "checkout")
+	resource.SetDroppedAttributesCount(10)
+	return resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	_, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp("STATUS_CODE_ERROR")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlscope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refIl, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newLibrary",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newLibrary")
+			},
+		},
+		{
+			name: "version",
+			path: []tql.Field{
+				{
+					Name: "version",
+				},
+			},
+			orig:   "version",
+			newVal: "newVersion",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetVersion("newVersion")
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refIl.Attributes(),
+			newVal: newAttrs,
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(il.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("owner"),
+				},
+			},
+			orig:   "team",
+			newVal: "other team",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.Attributes().PutString("owner", "other team")
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutString("service.name", "cart")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			il, resource := createTelemetry()
+
+			got := accessor.Get(NewTransformContext(il, resource))
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(NewTransformContext(il, resource), tt.newVal)
+
+			exIl, exRes := createTelemetry()
+			tt.modified(exIl, exRes)
+
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "unknown",
+				},
+			},
+		},
+		{
+			name: "unknown resource field",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "unknown",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (pcommon.InstrumentationScope, pcommon.Resource) {
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+	il.Attributes().PutString("owner", "team")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutString("service.name", "checkout")
+
+	return il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	_, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp("STATUS_CODE_ERROR")))
+	assert.Error(t, err)
+}
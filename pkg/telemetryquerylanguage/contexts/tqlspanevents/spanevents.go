@@ -0,0 +1,196 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlspanevents // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type transformContext struct {
+	spanEvent            ptrace.SpanEvent
+	span                 ptrace.Span
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+}
+
+func NewTransformContext(spanEvent ptrace.SpanEvent, span ptrace.Span, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) tql.TransformContext {
+	return transformContext{
+		spanEvent:            spanEvent,
+		span:                 span,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+	}
+}
+
+func (ctx transformContext) GetItem() interface{} {
+	return ctx.spanEvent
+}
+
+func (ctx transformContext) GetSpan() ptrace.Span {
+	return ctx.span
+}
+
+func (ctx transformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.instrumentationScope
+}
+
+func (ctx transformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// ParseEnum supports the same enums as the Traces Context.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	return tqltraces.ParseEnum(val)
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		return tqlcommon.ResourcePathGetSetter(path[1:])
+	case "instrumentation_library":
+		return tqlcommon.ScopePathGetSetter(path[1:])
+	case "span":
+		return spanPathGetSetter(path[1:])
+	case "time_unix_nano":
+		return accessTimeUnixNano(), nil
+	case "name":
+		return accessName(), nil
+	case "attributes":
+		mapKey := path[0].MapKey
+		if mapKey == nil {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(mapKey), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount(), nil
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+}
+
+// spanPathGetSetter gives access to the span the event belongs to by evaluating
+// the rest of the path in the Traces Context.
+func spanPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	if len(path) == 0 {
+		return accessSpan(), nil
+	}
+	getSetter, err := tqltraces.ParsePath(&tql.Path{Fields: path})
+	if err != nil {
+		return nil, err
+	}
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return getSetter.Get(spanTransformContext(ctx))
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			getSetter.Set(spanTransformContext(ctx), val)
+		},
+	}, nil
+}
+
+func spanTransformContext(ctx tql.TransformContext) tql.TransformContext {
+	return tqltraces.NewTransformContext(ctx.(transformContext).GetSpan(), ctx.GetInstrumentationScope(), ctx.GetResource())
+}
+
+func accessSpan() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.(transformContext).GetSpan()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newSpan, ok := val.(ptrace.Span); ok {
+				newSpan.CopyTo(ctx.(transformContext).GetSpan())
+			}
+		},
+	}
+}
+
+func accessTimeUnixNano() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Timestamp().AsTime().UnixNano()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+		},
+	}
+}
+
+func accessName() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Name()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetName(str)
+			}
+		},
+	}
+}
+
+func accessAttributes() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanEvent).Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanEvent).Attributes())
+			}
+		},
+	}
+}
+
+func accessAttributesKey(mapKey *string) tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return tqlcommon.GetMapValue(ctx.GetItem().(ptrace.SpanEvent).Attributes(), *mapKey)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			tqlcommon.SetMapValue(ctx.GetItem().(ptrace.SpanEvent).Attributes(), *mapKey, val)
+		},
+	}
+}
+
+func accessDroppedAttributesCount() tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return int64(ctx.GetItem().(ptrace.SpanEvent).DroppedAttributesCount())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanEvent).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
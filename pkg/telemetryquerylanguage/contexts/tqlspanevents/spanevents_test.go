@@ -0,0 +1,277 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlspanevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refSpanEvent, refSpan, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutString("hello", "world")
+
+	newSpan := ptrace.NewSpan()
+	newSpan.SetName("new span")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "time_unix_nano",
+			path: []tql.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "exception",
+			newVal: "cat",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetName("cat")
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refSpanEvent.Attributes(),
+			newVal: newAttrs,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(spanEvent.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("exception.type"),
+				},
+			},
+			orig:   "java.lang.NullPointerException",
+			newVal: "newVal",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.Attributes().PutString("exception.type", "newVal")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "span",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+			},
+			orig:   refSpan,
+			newVal: newSpan,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newSpan.CopyTo(span)
+			},
+		},
+		{
+			name: "span name",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "bear",
+			newVal: "cat",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetName("cat")
+			},
+		},
+		{
+			name: "span status code",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+				{
+					Name: "code",
+				},
+			},
+			orig:   int64(ptrace.StatusCodeError),
+			newVal: int64(ptrace.StatusCodeOk),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Status().SetCode(ptrace.StatusCodeOk)
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: tqltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutString("service.name", "cart")
+			},
+		},
+		{
+			name: "instrumentation_library name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_library",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newLibrary",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newLibrary")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			spanEvent, span, il, resource := createTelemetry()
+
+			got := accessor.Get(NewTransformContext(spanEvent, span, il, resource))
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(NewTransformContext(spanEvent, span, il, resource), tt.newVal)
+
+			exSpanEvent, exSpan, exIl, exRes := createTelemetry()
+			tt.modified(exSpanEvent, exSpan, exIl, exRes)
+
+			assert.Equal(t, exSpanEvent, spanEvent)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{
+				{
+					Name: "unknown",
+				},
+			},
+		},
+		{
+			name: "unknown span field",
+			path: []tql.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "unknown",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (ptrace.SpanEvent, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span := ptrace.NewSpan()
+	span.SetName("bear")
+	span.Status().SetCode(ptrace.StatusCodeError)
+
+	spanEvent := span.Events().AppendEmpty()
+	spanEvent.SetName("exception")
+	spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	spanEvent.Attributes().PutString("exception.type", "java.lang.NullPointerException")
+	spanEvent.SetDroppedAttributesCount(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutString("service.name", "checkout")
+
+	return spanEvent, span, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	enum, err := ParseEnum((*tql.EnumSymbol)(tqltest.Strp("STATUS_CODE_ERROR")))
+	assert.NoError(t, err)
+	assert.Equal(t, tql.Enum(ptrace.StatusCodeError), *enum)
+
+	_, err = ParseEnum((*tql.EnumSymbol)(tqltest.Strp("NOT_AN_ENUM")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ConvertCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		toCase   string
+		expected interface{}
+	}{
+		{
+			name:     "lower",
+			value:    "Hello World",
+			toCase:   "lower",
+			expected: "hello world",
+		},
+		{
+			name:     "upper",
+			value:    "Hello World",
+			toCase:   "upper",
+			expected: "HELLO WORLD",
+		},
+		{
+			name:     "snake from camel",
+			value:    "HTTPRequestDuration2Seconds",
+			toCase:   "snake",
+			expected: "http_request_duration2_seconds",
+		},
+		{
+			name:     "snake from separators",
+			value:    "http.request-duration seconds",
+			toCase:   "snake",
+			expected: "http_request_duration_seconds",
+		},
+		{
+			name:     "camel",
+			value:    "http_request.duration",
+			toCase:   "camel",
+			expected: "HttpRequestDuration",
+		},
+		{
+			name:     "camel from camel",
+			value:    "userID",
+			toCase:   "camel",
+			expected: "UserId",
+		},
+		{
+			name:     "empty",
+			value:    "",
+			toCase:   "snake",
+			expected: "",
+		},
+		{
+			name:     "not a string",
+			value:    int64(1),
+			toCase:   "upper",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ConvertCase(&tql.StandardGetSetter{
+				Getter: func(tql.TransformContext) interface{} {
+					return tt.value
+				},
+			}, tt.toCase)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_ConvertCase_invalid_case(t *testing.T) {
+	_, err := ConvertCase(&tql.StandardGetSetter{}, "kebab")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ExtractPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		pattern  string
+		expected interface{}
+	}{
+		{
+			name:    "named captures",
+			target:  "GET /api/v1/users 200",
+			pattern: `^(?P<method>\w+) (?P<path>\S+) (?P<status>\d+)$`,
+			expected: map[string]interface{}{
+				"method": "GET",
+				"path":   "/api/v1/users",
+				"status": "200",
+			},
+		},
+		{
+			name:    "unnamed captures are ignored",
+			target:  "user=john id=12",
+			pattern: `user=(\w+) id=(?P<id>\d+)`,
+			expected: map[string]interface{}{
+				"id": "12",
+			},
+		},
+		{
+			name:    "optional capture not matched",
+			target:  "error",
+			pattern: `(?P<level>\w+)(: (?P<message>.*))?`,
+			expected: map[string]interface{}{
+				"level":   "error",
+				"message": "",
+			},
+		},
+		{
+			name:     "no match",
+			target:   "GET",
+			pattern:  `(?P<status>\d+)`,
+			expected: nil,
+		},
+		{
+			name:     "not a string",
+			target:   int64(200),
+			pattern:  `(?P<status>\d+)`,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.target
+				},
+			}
+
+			exprFunc, err := ExtractPatterns(target, tt.pattern)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_ExtractPatterns_bad_pattern(t *testing.T) {
+	target := &tql.StandardGetSetter{}
+
+	_, err := ExtractPatterns(target, `(?P<status>\d+`)
+	assert.Error(t, err)
+
+	_, err = ExtractPatterns(target, `(\d+)`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+
+import (
+	// #nosec
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"hash/fnv"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func SHA256(target tql.Getter) (tql.ExprFunc, error) {
+	return hexHash(target, sha256.New), nil
+}
+
+func SHA1(target tql.Getter) (tql.ExprFunc, error) {
+	return hexHash(target, sha1.New), nil
+}
+
+func FNV(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		b, ok := hashInput(target.Get(ctx))
+		if !ok {
+			return nil
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(b)
+		return int64(h.Sum64())
+	}, nil
+}
+
+// hexHash returns a function hashing the target and returning the hex encoded hash.
+func hexHash(target tql.Getter, newHash func() hash.Hash) tql.ExprFunc {
+	return func(ctx tql.TransformContext) interface{} {
+		b, ok := hashInput(target.Get(ctx))
+		if !ok {
+			return nil
+		}
+		h := newHash()
+		_, _ = h.Write(b)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+}
+
+// hashInput returns the bytes hashed for the value. They are the same as the
+// ones hashed by the hash action of the attributes processor, so that both
+// produce the same hashes.
+func hashInput(val interface{}) ([]byte, bool) {
+	switch v := val.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	case bool:
+		if v {
+			return []byte{1}, true
+		}
+		return []byte{0}, true
+	case int64:
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, uint64(v))
+		return b, true
+	case float64:
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		return b, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		function func(tql.Getter) (tql.ExprFunc, error)
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "SHA256 string",
+			function: SHA256,
+			value:    "hello",
+			expected: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			name:     "SHA1 string",
+			function: SHA1,
+			value:    "hello",
+			expected: "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		},
+		{
+			name:     "SHA1 bytes",
+			function: SHA1,
+			value:    []byte("hello"),
+			expected: "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		},
+		{
+			name:     "SHA1 int",
+			function: SHA1,
+			value:    int64(1),
+			expected: "3da89ee273be13437e7ecf760f3fbd4dc0e8d1fe",
+		},
+		{
+			name:     "FNV string",
+			function: FNV,
+			value:    "hello",
+			expected: int64(-6615550055289275125),
+		},
+		{
+			name:     "SHA256 nil",
+			function: SHA256,
+			value:    nil,
+			expected: nil,
+		},
+		{
+			name:     "FNV map",
+			function: FNV,
+			value:    map[string]interface{}{},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := tt.function(&tql.StandardGetSetter{
+				Getter: func(tql.TransformContext) interface{} {
+					return tt.value
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
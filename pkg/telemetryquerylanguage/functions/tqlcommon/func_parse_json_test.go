@@ -0,0 +1,92 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ParseJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected interface{}
+	}{
+		{
+			name:   "flat object",
+			target: `{"level":"info","status":200,"duration":1.5,"cached":false}`,
+			expected: map[string]interface{}{
+				"level":    "info",
+				"status":   int64(200),
+				"duration": 1.5,
+				"cached":   false,
+			},
+		},
+		{
+			name:   "nested object and list",
+			target: `{"http":{"method":"GET","codes":[200,404]},"tags":null}`,
+			expected: map[string]interface{}{
+				"http": map[string]interface{}{
+					"method": "GET",
+					"codes":  []interface{}{int64(200), int64(404)},
+				},
+				"tags": nil,
+			},
+		},
+		{
+			name:     "not an object",
+			target:   `["a", "b"]`,
+			expected: nil,
+		},
+		{
+			name:     "invalid json",
+			target:   `{"level":`,
+			expected: nil,
+		},
+		{
+			name:     "trailing data",
+			target:   `{"level":"info"} {"level":"warn"}`,
+			expected: nil,
+		},
+		{
+			name:     "not a string",
+			target:   int64(1),
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.target
+				},
+			}
+
+			exprFunc, err := ParseJSON(target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
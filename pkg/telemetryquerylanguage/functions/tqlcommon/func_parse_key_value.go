@@ -0,0 +1,59 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func ParseKeyValue(target tql.Getter, delimiter string, pairDelimiter string) (tql.ExprFunc, error) {
+	if delimiter == "" {
+		return nil, fmt.Errorf("the delimiter supplied to ParseKeyValue cannot be empty")
+	}
+	if delimiter == pairDelimiter {
+		return nil, fmt.Errorf("the delimiter and the pair delimiter supplied to ParseKeyValue cannot be the same")
+	}
+
+	splitPairs := strings.Fields
+	if pairDelimiter != "" {
+		splitPairs = func(s string) []string {
+			return strings.Split(s, pairDelimiter)
+		}
+	}
+
+	return func(ctx tql.TransformContext) interface{} {
+		valStr, ok := target.Get(ctx).(string)
+		if !ok {
+			return nil
+		}
+
+		parsed := make(map[string]interface{})
+		for _, pair := range splitPairs(valStr) {
+			key, value, found := strings.Cut(pair, delimiter)
+			if !found {
+				continue
+			}
+			key = strings.Trim(strings.TrimSpace(key), "\"'")
+			if key == "" {
+				continue
+			}
+			parsed[key] = strings.Trim(strings.TrimSpace(value), "\"'")
+		}
+		return parsed
+	}, nil
+}
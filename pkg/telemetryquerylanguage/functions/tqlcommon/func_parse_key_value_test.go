@@ -0,0 +1,103 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        interface{}
+		delimiter     string
+		pairDelimiter string
+		expected      interface{}
+	}{
+		{
+			name:          "whitespace separated pairs",
+			target:        `name=test  user="john"	id=1`,
+			delimiter:     "=",
+			pairDelimiter: "",
+			expected: map[string]interface{}{
+				"name": "test",
+				"user": "john",
+				"id":   "1",
+			},
+		},
+		{
+			name:          "custom delimiters",
+			target:        `name: test; user: 'john doe'; empty: ; invalid`,
+			delimiter:     ":",
+			pairDelimiter: ";",
+			expected: map[string]interface{}{
+				"name":  "test",
+				"user":  "john doe",
+				"empty": "",
+			},
+		},
+		{
+			name:          "value containing the delimiter",
+			target:        "query=a=b",
+			delimiter:     "=",
+			pairDelimiter: "&",
+			expected: map[string]interface{}{
+				"query": "a=b",
+			},
+		},
+		{
+			name:          "no pairs",
+			target:        "",
+			delimiter:     "=",
+			pairDelimiter: "",
+			expected:      map[string]interface{}{},
+		},
+		{
+			name:          "not a string",
+			target:        int64(1),
+			delimiter:     "=",
+			pairDelimiter: "",
+			expected:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.target
+				},
+			}
+
+			exprFunc, err := ParseKeyValue(target, tt.delimiter, tt.pairDelimiter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_ParseKeyValue_invalid_delimiters(t *testing.T) {
+	target := &tql.StandardGetSetter{}
+
+	_, err := ParseKeyValue(target, "", "&")
+	assert.Error(t, err)
+
+	_, err = ParseKeyValue(target, "&", "&")
+	assert.Error(t, err)
+}
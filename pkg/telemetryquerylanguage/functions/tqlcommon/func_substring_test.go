@@ -0,0 +1,89 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Substring(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		start    int64
+		length   int64
+		expected interface{}
+	}{
+		{
+			name:     "prefix",
+			value:    "123456789",
+			start:    0,
+			length:   3,
+			expected: "123",
+		},
+		{
+			name:     "middle",
+			value:    "123456789",
+			start:    3,
+			length:   4,
+			expected: "4567",
+		},
+		{
+			name:     "whole string",
+			value:    "123456789",
+			start:    0,
+			length:   9,
+			expected: "123456789",
+		},
+		{
+			name:     "out of range",
+			value:    "123456789",
+			start:    5,
+			length:   5,
+			expected: nil,
+		},
+		{
+			name:     "not a string",
+			value:    int64(123456789),
+			start:    0,
+			length:   3,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Substring(&tql.StandardGetSetter{
+				Getter: func(tql.TransformContext) interface{} {
+					return tt.value
+				},
+			}, tt.start, tt.length)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_Substring_invalid_arguments(t *testing.T) {
+	_, err := Substring(&tql.StandardGetSetter{}, -1, 3)
+	assert.Error(t, err)
+
+	_, err = Substring(&tql.StandardGetSetter{}, 0, 0)
+	assert.Error(t, err)
+}
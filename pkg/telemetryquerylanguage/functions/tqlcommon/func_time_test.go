@@ -0,0 +1,111 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		layout   string
+		expected interface{}
+	}{
+		{
+			name:     "date and time",
+			value:    "2022-10-01 12:30:45",
+			layout:   "2006-01-02 15:04:05",
+			expected: time.Date(2022, 10, 1, 12, 30, 45, 0, time.UTC),
+		},
+		{
+			name:     "with time zone",
+			value:    "2022-10-01T12:30:45.123+02:00",
+			layout:   time.RFC3339Nano,
+			expected: time.Date(2022, 10, 1, 10, 30, 45, 123000000, time.UTC),
+		},
+		{
+			name:     "does not match layout",
+			value:    "01/10/2022",
+			layout:   "2006-01-02",
+			expected: nil,
+		},
+		{
+			name:     "not a string",
+			value:    int64(1),
+			layout:   "2006-01-02",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Time(&tql.StandardGetSetter{
+				Getter: func(tql.TransformContext) interface{} {
+					return tt.value
+				},
+			}, tt.layout)
+			assert.NoError(t, err)
+
+			actual := exprFunc(tqltest.TestTransformContext{})
+			if expected, ok := tt.expected.(time.Time); ok {
+				assert.True(t, expected.Equal(actual.(time.Time)), "expected %v, got %v", expected, actual)
+				return
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_Time_empty_layout(t *testing.T) {
+	_, err := Time(&tql.StandardGetSetter{}, "")
+	assert.Error(t, err)
+}
+
+func Test_UnixNano(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "time",
+			value:    time.Date(2022, 10, 1, 12, 30, 45, 1, time.UTC),
+			expected: int64(1664627445000000001),
+		},
+		{
+			name:     "string",
+			value:    "2022-10-01",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := UnixNano(&tql.StandardGetSetter{
+				Getter: func(tql.TransformContext) interface{} {
+					return tt.value
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
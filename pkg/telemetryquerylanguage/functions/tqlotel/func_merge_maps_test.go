@@ -0,0 +1,162 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlotel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_MergeMaps(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutString("attr1", "value1")
+	input.PutString("attr2", "value2")
+
+	targetGetter := &tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem()
+		},
+	}
+
+	tests := []struct {
+		name     string
+		source   interface{}
+		strategy string
+		want     func(pcommon.Map)
+	}{
+		{
+			name:     "upsert",
+			source:   map[string]interface{}{"attr2": "new", "attr3": int64(3)},
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "new")
+				expectedMap.PutInt("attr3", 3)
+			},
+		},
+		{
+			name:     "insert",
+			source:   map[string]interface{}{"attr2": "new", "attr3": int64(3)},
+			strategy: "insert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "value2")
+				expectedMap.PutInt("attr3", 3)
+			},
+		},
+		{
+			name:     "update",
+			source:   map[string]interface{}{"attr2": "new", "attr3": int64(3)},
+			strategy: "update",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "new")
+			},
+		},
+		{
+			name: "nested source map",
+			source: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutEmptyMap("http").PutString("method", "GET")
+				return m
+			}(),
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "value2")
+				expectedMap.PutEmptyMap("http").PutString("method", "GET")
+			},
+		},
+		{
+			name:     "source is not a map",
+			source:   "attr3=3",
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "value2")
+			},
+		},
+		{
+			name:     "source is nil",
+			source:   nil,
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutString("attr1", "value1")
+				expectedMap.PutString("attr2", "value2")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			ctx := tqltest.TestTransformContext{
+				Item: scenarioMap,
+			}
+
+			source := &tql.StandardGetSetter{
+				Getter: func(ctx tql.TransformContext) interface{} {
+					return tt.source
+				},
+			}
+
+			exprFunc, err := MergeMaps(targetGetter, source, tt.strategy)
+			assert.NoError(t, err)
+			exprFunc(ctx)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected, scenarioMap)
+		})
+	}
+}
+
+func Test_MergeMaps_bad_target(t *testing.T) {
+	input := pcommon.NewValueString("not a map")
+	ctx := tqltest.TestTransformContext{
+		Item: input,
+	}
+
+	target := &tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem()
+		},
+	}
+	source := &tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return map[string]interface{}{"attr": "value"}
+		},
+	}
+
+	exprFunc, err := MergeMaps(target, source, "upsert")
+	assert.NoError(t, err)
+	exprFunc(ctx)
+
+	assert.Equal(t, pcommon.NewValueString("not a map"), input)
+}
+
+func Test_MergeMaps_invalid_strategy(t *testing.T) {
+	target := &tql.StandardGetSetter{}
+
+	_, err := MergeMaps(target, target, "replace")
+	assert.Error(t, err)
+}
@@ -55,6 +55,13 @@ func orFuncs(funcs []boolExpressionEvaluator) boolExpressionEvaluator {
 	}
 }
 
+// builds a function that returns the negated result of a boolExpressionEvaluator func
+func notFunc(f boolExpressionEvaluator) boolExpressionEvaluator {
+	return func(ctx TransformContext) bool {
+		return !f(ctx)
+	}
+}
+
 func (p *Parser) newComparisonEvaluator(comparison *Comparison) (boolExpressionEvaluator, error) {
 	if comparison == nil {
 		return alwaysTrue, nil
@@ -121,6 +128,17 @@ func (p *Parser) newBooleanValueEvaluator(value *BooleanValue) (boolExpressionEv
 	if value == nil {
 		return alwaysTrue, nil
 	}
+	f, err := p.newUnnegatedBooleanValueEvaluator(value)
+	if err != nil {
+		return nil, err
+	}
+	if value.Negation != nil {
+		return notFunc(f), nil
+	}
+	return f, nil
+}
+
+func (p *Parser) newUnnegatedBooleanValueEvaluator(value *BooleanValue) (boolExpressionEvaluator, error) {
 	switch {
 	case value.Comparison != nil:
 		comparison, err := p.newComparisonEvaluator(value.Comparison)
@@ -346,6 +346,58 @@ func Test_newBooleanExpressionEvaluator(t *testing.T) {
 				},
 			},
 		},
+		{"i", false,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation:  tqltest.Strp("not"),
+						ConstExpr: Booleanp(true),
+					},
+				},
+			},
+		},
+		{"j", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									ConstExpr: Booleanp(true),
+								},
+								Right: []*OpAndBooleanValue{
+									{
+										Operator: "and",
+										Value: &BooleanValue{
+											ConstExpr: Booleanp(false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{"k", true,
+			&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						Comparison: &Comparison{
+							Left: Value{
+								String: tqltest.Strp("bear"),
+							},
+							Op: EQ,
+							Right: Value{
+								String: tqltest.Strp("cat"),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
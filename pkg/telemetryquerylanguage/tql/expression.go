@@ -103,6 +103,18 @@ func (p *Parser) newGetter(val Value) (Getter, error) {
 		return p.pathParser(val.Path)
 	}
 
+	if val.MathExpression != nil {
+		return p.evaluateMathExpression(val.MathExpression)
+	}
+
+	if val.List != nil {
+		return p.newListGetter(val.List)
+	}
+
+	if val.Map != nil {
+		return p.newMapGetter(val.Map)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the Telemetry Query Language")
@@ -115,3 +127,51 @@ func (p *Parser) newGetter(val Value) (Getter, error) {
 		expr: call,
 	}, nil
 }
+
+type listGetter struct {
+	values []Getter
+}
+
+func (l *listGetter) Get(ctx TransformContext) interface{} {
+	evaluated := make([]interface{}, len(l.values))
+	for i, v := range l.values {
+		evaluated[i] = v.Get(ctx)
+	}
+	return evaluated
+}
+
+func (p *Parser) newListGetter(list *List) (Getter, error) {
+	values := make([]Getter, len(list.Values))
+	for i, v := range list.Values {
+		getter, err := p.newGetter(v)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = getter
+	}
+	return &listGetter{values: values}, nil
+}
+
+type mapGetter struct {
+	values map[string]Getter
+}
+
+func (m *mapGetter) Get(ctx TransformContext) interface{} {
+	evaluated := make(map[string]interface{}, len(m.values))
+	for k, v := range m.values {
+		evaluated[k] = v.Get(ctx)
+	}
+	return evaluated
+}
+
+func (p *Parser) newMapGetter(m *Map) (Getter, error) {
+	values := make(map[string]Getter, len(m.Items))
+	for _, item := range m.Items {
+		getter, err := p.newGetter(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		values[item.Key] = getter
+	}
+	return &mapGetter{values: values}, nil
+}
@@ -105,6 +105,62 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: int64(1),
 		},
+		{
+			name: "list",
+			val: Value{
+				List: &List{
+					Values: []Value{
+						{
+							String: tqltest.Strp("a"),
+						},
+						{
+							Int: tqltest.Intp(1),
+						},
+						{
+							Invocation: &Invocation{
+								Function: "hello",
+							},
+						},
+					},
+				},
+			},
+			want: []interface{}{"a", int64(1), "world"},
+		},
+		{
+			name: "empty list",
+			val: Value{
+				List: &List{},
+			},
+			want: []interface{}{},
+		},
+		{
+			name: "map",
+			val: Value{
+				Map: &Map{
+					Items: []MapItem{
+						{
+							Key: "a",
+							Value: Value{
+								Float: tqltest.Floatp(1.5),
+							},
+						},
+						{
+							Key: "b",
+							Value: Value{
+								List: &List{
+									Values: []Value{
+										{
+											Bool: (*Boolean)(tqltest.Boolp(false)),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{"a": 1.5, "b": []interface{}{false}},
+		},
 	}
 
 	functions := map[string]interface{}{"hello": hello}
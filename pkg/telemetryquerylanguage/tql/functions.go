@@ -91,6 +91,13 @@ func (p *Parser) buildArgs(inv Invocation, fType reflect.Type) ([]reflect.Value,
 }
 
 func (p *Parser) buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value) error {
+	// A single list literal can be passed in place of the individual values, e.g. `["a", "b"]` instead of `"a", "b"`.
+	if len(inv.Arguments) == startingIndex+1 && inv.Arguments[startingIndex].List != nil {
+		arguments := make([]Value, 0, startingIndex+len(inv.Arguments[startingIndex].List.Values))
+		arguments = append(arguments, inv.Arguments[:startingIndex]...)
+		inv.Arguments = append(arguments, inv.Arguments[startingIndex].List.Values...)
+	}
+
 	switch argType.Elem().Name() {
 	case reflect.String.String():
 		var arg []string
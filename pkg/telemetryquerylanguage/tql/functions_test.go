@@ -33,6 +33,7 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 	functions["testing_byte_slice"] = functionWithByteSlice
 	functions["testing_enum"] = functionWithEnum
 	functions["testing_logger_first"] = functionWithLoggerFirst
+	functions["testing_string_slice"] = functionWithStringSlice
 
 	p := NewParser(
 		functions,
@@ -52,6 +53,26 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				Arguments: []Value{},
 			},
 		},
+		{
+			name: "list with invalid element for string slice",
+			inv: Invocation{
+				Function: "testing_string_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									Int: tqltest.Intp(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "not accessor",
 			inv: Invocation{
@@ -319,6 +340,46 @@ func Test_NewFunctionCall(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "string slice arg as list",
+			inv: Invocation{
+				Function: "testing_string_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									String: tqltest.Strp("test"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "getter slice arg as list",
+			inv: Invocation{
+				Function: "testing_getter_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									Int: tqltest.Intp(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "setter arg",
 			inv: Invocation{
@@ -83,7 +83,38 @@ func Test_lexer(t *testing.T) {
 			{"OpOr", "or"},
 			{"Lowercase", "but"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"parse_not", "not true", false, []result{
+			{"OpNot", "not"},
+			{"Boolean", "true"},
+		}},
+		{"name_containing_not", "notify cannot", false, []result{
+			{"Lowercase", "notify"},
+			{"Lowercase", "cannot"}, // should not parse "not" as an operator
+		}},
+		{"math_operators", "1 + 2.5 - 3 * 4 / -5", false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"Float", "2.5"},
+			{"OpAddSub", "-"},
+			{"Int", "3"},
+			{"OpMultDiv", "*"},
+			{"Int", "4"},
+			{"OpMultDiv", "/"},
+			{"OpAddSub", "-"},
+			{"Int", "5"},
+		}},
+		{"list_and_map", `[1, {"a": 2}]`, false, []result{
+			{"Punct", "["},
+			{"Int", "1"},
+			{"Punct", ","},
+			{"Punct", "{"},
+			{"String", `"a"`},
+			{"Punct", ":"},
+			{"Int", "2"},
+			{"Punct", "}"},
+			{"Punct", "]"},
+		}},
+		{"nothing_recognizable", "|", true, []result{
 			{"", ""},
 		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
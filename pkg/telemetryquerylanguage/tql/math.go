@@ -0,0 +1,130 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"fmt"
+)
+
+func (p *Parser) evaluateMathExpression(expr *MathExpression) (Getter, error) {
+	mainGetter, err := p.evaluateAddSubTerm(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		getter, err := p.evaluateAddSubTerm(rhs.Term)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter = attemptMathOperation(mainGetter, rhs.Operator, getter)
+	}
+
+	return mainGetter, nil
+}
+
+func (p *Parser) evaluateAddSubTerm(term *AddSubTerm) (Getter, error) {
+	mainGetter, err := p.evaluateMathValue(term.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		getter, err := p.evaluateMathValue(rhs.Value)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter = attemptMathOperation(mainGetter, rhs.Operator, getter)
+	}
+
+	return mainGetter, nil
+}
+
+func (p *Parser) evaluateMathValue(val *MathValue) (Getter, error) {
+	switch {
+	case val.Literal != nil:
+		return p.newGetter(Value{
+			Invocation: val.Literal.Invocation,
+			Float:      val.Literal.Float,
+			Int:        val.Literal.Int,
+			Path:       val.Literal.Path,
+		})
+	case val.SubExpression != nil:
+		return p.evaluateMathExpression(val.SubExpression)
+	}
+
+	return nil, fmt.Errorf("unsupported mathematical value %v", val)
+}
+
+// attemptMathOperation returns a Getter that applies op to the results of lhs and rhs.
+// Two int64 operands give an int64, an int64 and a float64 give a float64.
+// Any other operand types, as well as integer division by zero, evaluate to nil.
+func attemptMathOperation(lhs Getter, op MathOp, rhs Getter) Getter {
+	return exprGetter{
+		expr: func(ctx TransformContext) interface{} {
+			x := lhs.Get(ctx)
+			y := rhs.Get(ctx)
+			switch newX := x.(type) {
+			case int64:
+				switch newY := y.(type) {
+				case int64:
+					return performOpInt(newX, newY, op)
+				case float64:
+					return performOpFloat(float64(newX), newY, op)
+				}
+			case float64:
+				switch newY := y.(type) {
+				case int64:
+					return performOpFloat(newX, float64(newY), op)
+				case float64:
+					return performOpFloat(newX, newY, op)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func performOpInt(x int64, y int64, op MathOp) interface{} {
+	switch op {
+	case ADD:
+		return x + y
+	case SUB:
+		return x - y
+	case MULT:
+		return x * y
+	case DIV:
+		if y == 0 {
+			return nil
+		}
+		return x / y
+	}
+	return nil
+}
+
+func performOpFloat(x float64, y float64, op MathOp) interface{} {
+	switch op {
+	case ADD:
+		return x + y
+	case SUB:
+		return x - y
+	case MULT:
+		return x * y
+	case DIV:
+		if y == 0 {
+			return nil
+		}
+		return x / y
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func mathParsePath(val *Path) (GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 && val.Fields[0].Name == "one_hundred" {
+		return &testGetSetter{
+			getter: func(ctx TransformContext) interface{} {
+				return int64(100)
+			},
+		}, nil
+	}
+	return testParsePath(val)
+}
+
+func one() (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return int64(1)
+	}, nil
+}
+
+func two() (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return 2.0
+	}, nil
+}
+
+func Test_evaluateMathExpression(t *testing.T) {
+	functions := map[string]interface{}{
+		"one":   one,
+		"two":   two,
+		"hello": hello,
+	}
+
+	p := NewParser(
+		functions,
+		mathParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{"simple addition", "1 + 1", int64(2)},
+		{"simple subtraction", "10 - 4", int64(6)},
+		{"negative result", "4 - 10", int64(-6)},
+		{"negative operand", "-4 - -10", int64(6)},
+		{"without whitespace", "10-4", int64(6)},
+		{"simple multiplication", "3 * 4", int64(12)},
+		{"integer division", "7 / 2", int64(3)},
+		{"float addition", "1.5 + 2.25", 3.75},
+		{"mixed int and float", "1 + 0.5", 1.5},
+		{"float division", "7.0 / 2", 3.5},
+		{"precedence", "1 + 2 * 3", int64(7)},
+		{"left to right", "8 / 4 / 2", int64(1)},
+		{"parentheses", "(1 + 2) * 3", int64(9)},
+		{"nested parentheses", "((1 + 2) * (3 - 1)) / 2", int64(3)},
+		{"path", "one_hundred / 4", int64(25)},
+		{"functions", "one() + two()", 3.0},
+		{"integer division by zero", "1 / 0", nil},
+		{"float division by zero", "1.0 / 0", nil},
+		{"unsupported type", "hello() + 1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(" + tt.input + ")")
+			assert.NoError(t, err)
+			assert.NotNil(t, parsed.Invocation.Arguments[0].MathExpression)
+
+			getter, err := p.newGetter(parsed.Invocation.Arguments[0])
+			assert.NoError(t, err)
+
+			result := getter.Get(tqltest.TestTransformContext{})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
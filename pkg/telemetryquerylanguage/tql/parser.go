@@ -31,8 +31,9 @@ type ParsedQuery struct {
 
 // BooleanValue represents something that evaluates to a boolean --
 // either an equality or inequality, explicit true or false, or
-// a parenthesized subexpression.
+// a parenthesized subexpression, optionally negated with `not`.
 type BooleanValue struct {
+	Negation   *string            `parser:"@OpNot?"`
 	Comparison *Comparison        `parser:"( @@"`
 	ConstExpr  *Boolean           `parser:"| @Boolean"`
 	SubExpr    *BooleanExpression `parser:"| '(' @@ ')' )"`
@@ -130,19 +131,124 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal or math expression.
+// Invocations, numbers and paths are only captured on their own if they are not followed by a math operator,
+// otherwise they are captured as part of a MathExpression.
 type Value struct {
+	IsNil          *IsNil          `parser:"( @'nil'"`
+	Invocation     *Invocation     `parser:"| ( @@"`
+	Float          *float64        `parser:"| @(OpAddSub? Float)"`
+	Int            *int64          `parser:"| @(OpAddSub? Int)"`
+	Path           *Path           `parser:"| @@ ) (?! OpAddSub | OpMultDiv)"`
+	MathExpression *MathExpression `parser:"| @@"`
+	Bytes          *Bytes          `parser:"| @Bytes"`
+	String         *string         `parser:"| @String"`
+	Bool           *Boolean        `parser:"| @Boolean"`
+	Enum           *EnumSymbol     `parser:"| @Uppercase"`
+	List           *List           `parser:"| @@"`
+	Map            *Map            `parser:"| @@ )"`
+}
+
+// List represents a list literal of Values, e.g. `["a", "b"]`.
+type List struct {
+	Values []Value `parser:"'[' ( @@ ( ',' @@ )* )? ']'"`
+}
+
+// Map represents a map literal with string keys, e.g. `{"a": 1, "b": attributes["b"]}`.
+type Map struct {
+	Items []MapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+// MapItem is a single key and Value within a Map.
+type MapItem struct {
+	Key   string `parser:"@String ':'"`
+	Value Value  `parser:"@@"`
+}
+
+// MathExprLiteral represents the operands of a math expression.
+type MathExprLiteral struct {
 	Invocation *Invocation `parser:"( @@"`
-	Bytes      *Bytes      `parser:"| @Bytes"`
-	String     *string     `parser:"| @String"`
-	Float      *float64    `parser:"| @Float"`
-	Int        *int64      `parser:"| @Int"`
-	Bool       *Boolean    `parser:"| @Boolean"`
-	IsNil      *IsNil      `parser:"| @'nil'"`
-	Enum       *EnumSymbol `parser:"| @Uppercase"`
+	Float      *float64    `parser:"| @(OpAddSub? Float)"`
+	Int        *int64      `parser:"| @(OpAddSub? Int)"`
 	Path       *Path       `parser:"| @@ )"`
 }
 
+// MathValue represents an operand of a math expression, or a parenthesized math expression.
+type MathValue struct {
+	Literal       *MathExprLiteral `parser:"( @@"`
+	SubExpression *MathExpression  `parser:"| '(' @@ ')' )"`
+}
+
+// OpMultDivValue represents the right side of a multiplication or division.
+type OpMultDivValue struct {
+	Operator MathOp     `parser:"@OpMultDiv"`
+	Value    *MathValue `parser:"@@"`
+}
+
+// AddSubTerm represents an arbitrary number of math values joined by multiplication or division.
+type AddSubTerm struct {
+	Left  *MathValue        `parser:"@@"`
+	Right []*OpMultDivValue `parser:"@@*"`
+}
+
+// OpAddSubTerm represents the right side of an addition or subtraction.
+type OpAddSubTerm struct {
+	Operator MathOp      `parser:"@OpAddSub"`
+	Term     *AddSubTerm `parser:"@@"`
+}
+
+// MathExpression represents an arbitrary number of terms joined by addition or subtraction.
+// Multiplication and division have higher precedence than addition and subtraction.
+type MathExpression struct {
+	Left  *AddSubTerm     `parser:"@@"`
+	Right []*OpAddSubTerm `parser:"@@*"`
+}
+
+// MathOp is the type of a math operator.
+type MathOp int
+
+// These are the allowed values of a MathOp
+const (
+	ADD MathOp = iota
+	SUB
+	MULT
+	DIV
+)
+
+// a fast way to get from a string to a MathOp
+var mathOpTable = map[string]MathOp{
+	"+": ADD,
+	"-": SUB,
+	"*": MULT,
+	"/": DIV,
+}
+
+// Capture is how the parser converts an operator string to a MathOp.
+func (m *MathOp) Capture(values []string) error {
+	op, ok := mathOpTable[values[0]]
+	if !ok {
+		return fmt.Errorf("'%s' is not a valid operator", values[0])
+	}
+	*m = op
+	return nil
+}
+
+// String() for MathOp gives us more legible test results and error messages.
+func (m MathOp) String() string {
+	switch m {
+	case ADD:
+		return "+"
+	case SUB:
+		return "-"
+	case MULT:
+		return "*"
+	case DIV:
+		return "/"
+	default:
+		return "UNKNOWN OP!"
+	}
+}
+
 // Path represents a telemetry path expression.
 type Path struct {
 	Fields []Field `parser:"@@ ( '.' @@ )*"`
@@ -161,6 +267,9 @@ type Query struct {
 	Condition boolExpressionEvaluator
 }
 
+// Condition reports whether a parsed boolean expression is true for a TransformContext.
+type Condition func(ctx TransformContext) bool
+
 // Bytes type for capturing byte arrays
 type Bytes []byte
 
@@ -240,7 +349,35 @@ func (p *Parser) ParseQueries(statements []string) ([]Query, error) {
 	return queries, nil
 }
 
-var parser = newParser()
+// ParseConditions parses boolean expressions that are not part of a query, e.g. `name == "foo" and not IsMatch(attributes["bar"], "^baz")`.
+// The returned Conditions are in the same order as the conditions they were parsed from.
+func (p *Parser) ParseConditions(conditions []string) ([]Condition, error) {
+	var parsedConditions []Condition
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		expression, err := p.newBooleanExpressionEvaluator(parsed)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		parsedConditions = append(parsedConditions, Condition(expression))
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return parsedConditions, nil
+}
+
+var parser = newParser[ParsedQuery]()
+
+var conditionParser = newParser[BooleanExpression]()
 
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed, err := parser.ParseString("", raw)
@@ -250,36 +387,49 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*BooleanExpression, error) {
+	parsed, err := conditionParser.ParseString("", raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
+		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:\[\]{}]`},
 		{Name: `Uppercase`, Pattern: `[A-Z_][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z_][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
 	})
 }
 
-// newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser[ParsedQuery] {
+// newParser returns a parser that can be used to read a string into a ParsedQuery or any other part of the grammar.
+// An error will be returned if the string is not formatted for the DSL.
+func newParser[G any]() *participle.Parser[G] {
 	lex := buildLexer()
-	parser, err := participle.Build[ParsedQuery](
+	parser, err := participle.Build[G](
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// Math expressions can only be told apart from their operands once the operator is reached.
+		participle.UseLookahead(participle.MaxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
@@ -424,6 +424,220 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "invocation with negative numbers",
+			query: `fff(-12, -1.5)`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "fff",
+					Arguments: []Value{
+						{
+							Int: tqltest.Intp(-12),
+						},
+						{
+							Float: tqltest.Floatp(-1.5),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with list",
+			query: `set(attributes["test"], ["a", 1, [2.5]])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name:   "attributes",
+										MapKey: tqltest.Strp("test"),
+									},
+								},
+							},
+						},
+						{
+							List: &List{
+								Values: []Value{
+									{
+										String: tqltest.Strp("a"),
+									},
+									{
+										Int: tqltest.Intp(1),
+									},
+									{
+										List: &List{
+											Values: []Value{
+												{
+													Float: tqltest.Floatp(2.5),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with empty list and map",
+			query: `set([], {})`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							List: &List{},
+						},
+						{
+							Map: &Map{},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with map",
+			query: `set(attributes["test"], {"a": "b", "c": name})`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name:   "attributes",
+										MapKey: tqltest.Strp("test"),
+									},
+								},
+							},
+						},
+						{
+							Map: &Map{
+								Items: []MapItem{
+									{
+										Key: "a",
+										Value: Value{
+											String: tqltest.Strp("b"),
+										},
+									},
+									{
+										Key: "c",
+										Value: Value{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "invocation with math expression",
+			query: `set(attributes["test"], 1 + 2 * (name - 3.5) / f())`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name:   "attributes",
+										MapKey: tqltest.Strp("test"),
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Int: tqltest.Intp(1),
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: ADD,
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(2),
+												},
+											},
+											Right: []*OpMultDivValue{
+												{
+													Operator: MULT,
+													Value: &MathValue{
+														SubExpression: &MathExpression{
+															Left: &AddSubTerm{
+																Left: &MathValue{
+																	Literal: &MathExprLiteral{
+																		Path: &Path{
+																			Fields: []Field{
+																				{
+																					Name: "name",
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															Right: []*OpAddSubTerm{
+																{
+																	Operator: SUB,
+																	Term: &AddSubTerm{
+																		Left: &MathValue{
+																			Literal: &MathExprLiteral{
+																				Float: tqltest.Floatp(3.5),
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+												{
+													Operator: DIV,
+													Value: &MathValue{
+														Literal: &MathExprLiteral{
+															Invocation: &Invocation{
+																Function: "f",
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -457,6 +671,14 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set("foo") where not`,
+		`set("foo") where name == "fido" not`,
+		`set(["foo",])`,
+		`set({"foo"})`,
+		`set({foo: "bar"})`,
+		`set(1 +)`,
+		`set(1 * * 2)`,
+		`set("foo" + 1)`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -520,6 +742,52 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			query: `not true`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation:  tqltest.Strp("not"),
+						ConstExpr: Booleanp(true),
+					},
+				},
+			}),
+		},
+		{
+			query: `not (true or false) and true`,
+			expected: setNameTest(&BooleanExpression{
+				Left: &Term{
+					Left: &BooleanValue{
+						Negation: tqltest.Strp("not"),
+						SubExpr: &BooleanExpression{
+							Left: &Term{
+								Left: &BooleanValue{
+									ConstExpr: Booleanp(true),
+								},
+							},
+							Right: []*OpOrTerm{
+								{
+									Operator: "or",
+									Term: &Term{
+										Left: &BooleanValue{
+											ConstExpr: Booleanp(false),
+										},
+									},
+								},
+							},
+						},
+					},
+					Right: []*OpAndBooleanValue{
+						{
+							Operator: "and",
+							Value: &BooleanValue{
+								ConstExpr: Booleanp(true),
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			query: `true and false`,
 			expected: setNameTest(&BooleanExpression{
@@ -840,3 +1108,60 @@ func Test_parseQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseConditions(t *testing.T) {
+	p := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	tests := []struct {
+		condition string
+		item      interface{}
+		expected  bool
+	}{
+		{`name == "bear"`, "bear", true},
+		{`name == "bear"`, "cat", false},
+		{`not name == "bear"`, "cat", true},
+		{`name == "bear" or name == "cat"`, "cat", true},
+		{`name != nil and name != "cat"`, "bear", true},
+		{`(name == 1 + 2) and true`, int64(3), true},
+		{`name == TEST_ENUM_ONE`, int64(1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			conditions, err := p.ParseConditions([]string{tt.condition})
+			assert.NoError(t, err)
+			assert.Len(t, conditions, 1)
+			assert.Equal(t, tt.expected, conditions[0](tqltest.TestTransformContext{
+				Item: tt.item,
+			}))
+		})
+	}
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	p := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath,
+		testParseEnum,
+		NoOpLogger{},
+	)
+
+	tests := []string{
+		``,
+		`set(name, "bear") where name == "bear"`,
+		`name ==`,
+		`name = "bear"`,
+		`unknown == "bear"`,
+		`name == UNKNOWN_ENUM`,
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := p.ParseConditions([]string{`name == "bear"`, tt})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -16,12 +16,34 @@ package tqlconfig // import "github.com/open-telemetry/opentelemetry-collector-c
 
 // Config configures the TQL queries to execute against traces, metrics, and logs.
 type Config struct {
-	Traces  SignalConfig `mapstructure:"traces"`
-	Metrics SignalConfig `mapstructure:"metrics"`
-	Logs    SignalConfig `mapstructure:"logs"`
+	Traces  TracesConfig  `mapstructure:"traces"`
+	Metrics MetricsConfig `mapstructure:"metrics"`
+	Logs    SignalConfig  `mapstructure:"logs"`
 }
 
 // SignalConfig configures TQL queries to execute.
 type SignalConfig struct {
+	// Queries are executed once per span, data point or log record.
 	Queries []string `mapstructure:"queries"`
+	// ResourceQueries are executed once per resource, before the other queries.
+	ResourceQueries []string `mapstructure:"resource_queries"`
+	// ScopeQueries are executed once per instrumentation scope, after the
+	// resource queries.
+	ScopeQueries []string `mapstructure:"scope_queries"`
+}
+
+// TracesConfig configures TQL queries to execute against traces.
+type TracesConfig struct {
+	SignalConfig `mapstructure:",squash"`
+	// SpanEventQueries are executed once per span event, after the queries of
+	// the span the event belongs to.
+	SpanEventQueries []string `mapstructure:"span_event_queries"`
+}
+
+// MetricsConfig configures TQL queries to execute against metrics.
+type MetricsConfig struct {
+	SignalConfig `mapstructure:",squash"`
+	// MetricQueries are executed once per metric, before the queries of its
+	// data points.
+	MetricQueries []string `mapstructure:"metric_queries"`
 }
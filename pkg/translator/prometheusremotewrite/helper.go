@@ -250,6 +250,8 @@ func validateMetrics(metric pmetric.Metric) bool {
 		return metric.Sum().DataPoints().Len() != 0 && metric.Sum().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
 	case pmetric.MetricDataTypeHistogram:
 		return metric.Histogram().DataPoints().Len() != 0 && metric.Histogram().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len() != 0 && metric.ExponentialHistogram().AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
 	case pmetric.MetricDataTypeSummary:
 		return metric.Summary().DataPoints().Len() != 0
 	}
@@ -356,6 +358,67 @@ func addSingleHistogramDataPoint(pt pmetric.HistogramDataPoint, resource pcommon
 	addExemplars(tsMap, promExemplars, bucketBounds)
 }
 
+// convertExponentialHistogramDataPoint converts pt to a histogram data point with explicit bounds. The scale of pt is
+// reduced until its positive and negative buckets fit in maxBuckets, the negative buckets are then bounded by
+// -base^index, the zero bucket by 0 and the positive buckets by base^(index+1), with base = 2^(2^-scale).
+func convertExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, maxBuckets int) pmetric.HistogramDataPoint {
+	scale := pt.Scale()
+	positiveOffset, positive := pt.Positive().Offset(), pt.Positive().BucketCounts().AsRaw()
+	negativeOffset, negative := pt.Negative().Offset(), pt.Negative().BucketCounts().AsRaw()
+	for maxBuckets > 0 && len(positive)+len(negative) > maxBuckets && (len(positive) > 1 || len(negative) > 1) {
+		positiveOffset, positive = downscaleBuckets(positiveOffset, positive)
+		negativeOffset, negative = downscaleBuckets(negativeOffset, negative)
+		scale--
+	}
+
+	// base^index = 2^(index * 2^-scale)
+	factor := math.Exp2(-float64(scale))
+	bounds := make([]float64, 0, len(negative)+1+len(positive))
+	counts := make([]uint64, 0, len(negative)+2+len(positive))
+	for i := len(negative) - 1; i >= 0; i-- {
+		bounds = append(bounds, -math.Exp2(float64(negativeOffset+int32(i))*factor))
+		counts = append(counts, negative[i])
+	}
+	bounds = append(bounds, 0)
+	counts = append(counts, pt.ZeroCount())
+	for i := range positive {
+		bounds = append(bounds, math.Exp2(float64(positiveOffset+int32(i)+1)*factor))
+		counts = append(counts, positive[i])
+	}
+	// the last bucket is above the highest bound, every value is in a bounded bucket
+	counts = append(counts, 0)
+
+	histogramPt := pmetric.NewHistogramDataPoint()
+	pt.Attributes().CopyTo(histogramPt.Attributes())
+	histogramPt.SetStartTimestamp(pt.StartTimestamp())
+	histogramPt.SetTimestamp(pt.Timestamp())
+	histogramPt.SetFlags(pt.Flags())
+	histogramPt.SetCount(pt.Count())
+	if pt.HasSum() {
+		histogramPt.SetSum(pt.Sum())
+	}
+	histogramPt.ExplicitBounds().FromRaw(bounds)
+	histogramPt.BucketCounts().FromRaw(counts)
+	pt.Exemplars().CopyTo(histogramPt.Exemplars())
+	return histogramPt
+}
+
+// downscaleBuckets merges each pair of buckets starting at offset to halve their resolution, as reducing the scale
+// of an exponential histogram by 1 does, and returns the new offset and bucket counts.
+func downscaleBuckets(offset int32, counts []uint64) (int32, []uint64) {
+	// the index of a bucket at the lower scale is index >> 1, rounded towards negative infinity
+	newOffset := offset >> 1
+	if len(counts) == 0 {
+		return newOffset, counts
+	}
+	last := (offset + int32(len(counts)) - 1) >> 1
+	merged := make([]uint64, last-newOffset+1)
+	for i, count := range counts {
+		merged[(offset+int32(i))>>1-newOffset] += count
+	}
+	return newOffset, merged
+}
+
 func getPromExemplars(pt pmetric.HistogramDataPoint) []prompb.Exemplar {
 	var promExemplars []prompb.Exemplar
 
@@ -431,6 +494,11 @@ func mostRecentTimestampInMetric(metric pmetric.Metric) pcommon.Timestamp {
 		for x := 0; x < dataPoints.Len(); x++ {
 			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
 		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for x := 0; x < dataPoints.Len(); x++ {
+			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
+		}
 	case pmetric.MetricDataTypeSummary:
 		dataPoints := metric.Summary().DataPoints()
 		for x := 0; x < dataPoints.Len(); x++ {
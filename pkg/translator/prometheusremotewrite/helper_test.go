@@ -611,3 +611,124 @@ func TestMostRecentTimestampInMetric(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertExponentialHistogramDataPoint(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		scale          int32
+		zeroCount      uint64
+		positiveOffset int32
+		positive       []uint64
+		negativeOffset int32
+		negative       []uint64
+		maxBuckets     int
+		wantBounds     []float64
+		wantCounts     []uint64
+	}{
+		{
+			desc:           "positive buckets",
+			zeroCount:      3,
+			positiveOffset: 1,
+			positive:       []uint64{1, 2},
+			wantBounds:     []float64{0, 4, 8},
+			wantCounts:     []uint64{3, 1, 2, 0},
+		},
+		{
+			desc:           "negative buckets",
+			positiveOffset: 0,
+			positive:       []uint64{4},
+			negativeOffset: -1,
+			negative:       []uint64{1, 2},
+			wantBounds:     []float64{-1, -0.5, 0, 2},
+			wantCounts:     []uint64{2, 1, 0, 4, 0},
+		},
+		{
+			desc:           "bounds at a positive scale",
+			scale:          2,
+			positiveOffset: 4,
+			positive:       []uint64{1, 1},
+			wantBounds:     []float64{0, math.Exp2(5.0 / 4), math.Exp2(6.0 / 4)},
+			wantCounts:     []uint64{0, 1, 1, 0},
+		},
+		{
+			desc:           "scale reduced to fit max buckets",
+			scale:          2,
+			positiveOffset: 3,
+			positive:       []uint64{1, 1, 1, 1, 1},
+			maxBuckets:     2,
+			wantBounds:     []float64{0, 2, 4},
+			wantCounts:     []uint64{0, 1, 4, 0},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pt := pmetric.NewExponentialHistogramDataPoint()
+			pt.SetScale(tc.scale)
+			pt.SetZeroCount(tc.zeroCount)
+			pt.Positive().SetOffset(tc.positiveOffset)
+			pt.Positive().BucketCounts().FromRaw(tc.positive)
+			pt.Negative().SetOffset(tc.negativeOffset)
+			pt.Negative().BucketCounts().FromRaw(tc.negative)
+			pt.SetCount(10)
+			pt.SetSum(20)
+			pt.SetTimestamp(pcommon.Timestamp(time1))
+			pt.Attributes().PutString(label11, value11)
+
+			got := convertExponentialHistogramDataPoint(pt, tc.maxBuckets)
+			assert.Equal(t, tc.wantBounds, got.ExplicitBounds().AsRaw())
+			assert.Equal(t, tc.wantCounts, got.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(10), got.Count())
+			assert.True(t, got.HasSum())
+			assert.Equal(t, 20.0, got.Sum())
+			assert.Equal(t, pcommon.Timestamp(time1), got.Timestamp())
+			assert.Equal(t, pt.Attributes().AsRaw(), got.Attributes().AsRaw())
+		})
+	}
+}
+
+func Test_downscaleBuckets(t *testing.T) {
+	offset, counts := downscaleBuckets(-3, []uint64{1, 2, 3})
+	assert.Equal(t, int32(-2), offset)
+	assert.Equal(t, []uint64{1, 5}, counts)
+
+	offset, counts = downscaleBuckets(3, []uint64{1, 2, 3})
+	assert.Equal(t, int32(1), offset)
+	assert.Equal(t, []uint64{1, 5}, counts)
+
+	offset, counts = downscaleBuckets(5, nil)
+	assert.Equal(t, int32(2), offset)
+	assert.Empty(t, counts)
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := getExponentialHistogramMetric("test_exponential_histogram", lbs1, time1, 20, 3, 0, 1, []uint64{1, 2})
+	metric.CopyTo(md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty())
+
+	tsMap, err := FromMetrics(md, Settings{})
+	assert.EqualError(t, err, "exponential histograms are not exported. test_exponential_histogram is dropped")
+	assert.Empty(t, tsMap)
+
+	tsMap, err = FromMetrics(md, Settings{ExportExponentialHistograms: true})
+	assert.NoError(t, err)
+	got := map[string]float64{}
+	for _, ts := range tsMap {
+		var name, le string
+		for _, label := range ts.Labels {
+			switch label.Name {
+			case nameStr:
+				name = label.Value
+			case leStr:
+				le = label.Value
+			}
+		}
+		got[name+le] = ts.Samples[0].Value
+	}
+	assert.Equal(t, map[string]float64{
+		"test_exponential_histogram_sum":        20,
+		"test_exponential_histogram_count":      3,
+		"test_exponential_histogram_bucket0":    0,
+		"test_exponential_histogram_bucket4":    1,
+		"test_exponential_histogram_bucket8":    3,
+		"test_exponential_histogram_bucket+Inf": 3,
+	}, got)
+}
@@ -38,6 +38,13 @@ type Settings struct {
 	Namespace         string
 	ExternalLabels    map[string]string
 	DisableTargetInfo bool
+
+	// ExportExponentialHistograms converts exponential histograms to histograms with explicit bounds, they are
+	// dropped otherwise.
+	ExportExponentialHistograms bool
+	// ExponentialHistogramMaxBuckets is the maximum number of positive and negative buckets an exponential
+	// histogram is converted to, its scale is reduced until its buckets fit. There is no limit if it is 0.
+	ExponentialHistogramMaxBuckets int
 }
 
 // FromMetrics converts pmetric.Metrics to prometheus remote write format.
@@ -88,6 +95,16 @@ func FromMetrics(md pmetric.Metrics, settings Settings) (tsMap map[string]*promp
 					for x := 0; x < dataPoints.Len(); x++ {
 						addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, settings, tsMap)
 					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					if !settings.ExportExponentialHistograms {
+						errs = multierr.Append(errs, fmt.Errorf("exponential histograms are not exported. %s is dropped", metric.Name()))
+						continue
+					}
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := convertExponentialHistogramDataPoint(dataPoints.At(x), settings.ExponentialHistogramMaxBuckets)
+						addSingleHistogramDataPoint(pt, resource, metric, settings, tsMap)
+					}
 				case pmetric.MetricDataTypeSummary:
 					dataPoints := metric.Summary().DataPoints()
 					if dataPoints.Len() == 0 {
@@ -38,6 +38,8 @@ type Config struct {
 	Logs LogFilters `mapstructure:"logs"`
 
 	Spans SpanFilters `mapstructure:"spans"`
+
+	Traces TraceFilters `mapstructure:"traces"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -54,6 +56,20 @@ type MetricFilters struct {
 
 	// RegexpConfig specifies options for the Regexp match type
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
+
+	// MetricConditions is a list of telemetry query language conditions evaluated against
+	// each metric. A metric is dropped if any of the conditions is true for it.
+	MetricConditions []string `mapstructure:"metric"`
+
+	// DataPointConditions is a list of telemetry query language conditions evaluated against
+	// each data point. A data point is dropped if any of the conditions is true for it, and a
+	// metric is dropped once all its data points are.
+	DataPointConditions []string `mapstructure:"data_point"`
+}
+
+// hasConditions returns true if any telemetry query language condition is configured.
+func (mf MetricFilters) hasConditions() bool {
+	return len(mf.MetricConditions) > 0 || len(mf.DataPointConditions) > 0
 }
 
 // SpanFilters filters by Span attributes and various other fields, Regexp config is per matcher
@@ -69,6 +85,22 @@ type SpanFilters struct {
 	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
 }
 
+// TraceFilters filters spans and span events with telemetry query language conditions.
+type TraceFilters struct {
+	// SpanConditions is a list of conditions evaluated against each span. A span is dropped
+	// if any of the conditions is true for it.
+	SpanConditions []string `mapstructure:"span"`
+
+	// SpanEventConditions is a list of conditions evaluated against each span event. A span
+	// event is dropped if any of the conditions is true for it.
+	SpanEventConditions []string `mapstructure:"span_event"`
+}
+
+// hasConditions returns true if any telemetry query language condition is configured.
+func (tf TraceFilters) hasConditions() bool {
+	return len(tf.SpanConditions) > 0 || len(tf.SpanEventConditions) > 0
+}
+
 // LogFilters filters by Log properties.
 type LogFilters struct {
 	// Include match properties describe logs that should be included in the Collector Service pipeline,
@@ -79,6 +111,10 @@ type LogFilters struct {
 	// all other logs should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
+
+	// LogRecordConditions is a list of telemetry query language conditions evaluated against
+	// each log record. A log record is dropped if any of the conditions is true for it.
+	LogRecordConditions []string `mapstructure:"log_record"`
 }
 
 // LogMatchType specifies the strategy for matching against `plog.Log`s.
@@ -263,5 +299,26 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, cfg.Logs.Exclude.validate())
 	}
 
+	if cfg.Traces.hasConditions() && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
+		err = multierr.Append(err, errors.New("traces conditions cannot be used along with spans include or exclude"))
+	}
+	if cfg.Metrics.hasConditions() && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
+		err = multierr.Append(err, errors.New("metrics conditions cannot be used along with metrics include or exclude"))
+	}
+	if len(cfg.Logs.LogRecordConditions) > 0 && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
+		err = multierr.Append(err, errors.New("logs conditions cannot be used along with logs include or exclude"))
+	}
+
+	_, parseErr := spanParser.ParseConditions(cfg.Traces.SpanConditions)
+	err = multierr.Append(err, parseErr)
+	_, parseErr = spanEventParser.ParseConditions(cfg.Traces.SpanEventConditions)
+	err = multierr.Append(err, parseErr)
+	_, parseErr = metricParser.ParseConditions(cfg.Metrics.MetricConditions)
+	err = multierr.Append(err, parseErr)
+	_, parseErr = dataPointParser.ParseConditions(cfg.Metrics.DataPointConditions)
+	err = multierr.Append(err, parseErr)
+	_, parseErr = logRecordParser.ParseConditions(cfg.Logs.LogRecordConditions)
+	err = multierr.Append(err, parseErr)
+
 	return err
 }
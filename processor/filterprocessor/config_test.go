@@ -840,3 +840,69 @@ func TestLogSeverity_severityValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadingConfigTQL(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_tql.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           config.ComponentID
+		expected     config.Processor
+		errorMessage string
+	}{
+		{
+			id: config.NewComponentIDWithName("filter", "tql"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`attributes["test"] == "pass"`,
+						`name == "operationA"`,
+					},
+					SpanEventConditions: []string{
+						`name == "eventA"`,
+					},
+				},
+				Metrics: MetricFilters{
+					MetricConditions: []string{
+						`name == "metricA"`,
+					},
+					DataPointConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
+				},
+				Logs: LogFilters{
+					LogRecordConditions: []string{
+						`IsMatch(body, ".*password.*") == true`,
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName("filter", "invalid"),
+			errorMessage: "1:20: invalid input text \"= \\\"pass\\\"\"",
+		},
+		{
+			id:           config.NewComponentIDWithName("filter", "mixed"),
+			errorMessage: "traces conditions cannot be used along with spans include or exclude",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, cfg.Validate(), tt.errorMessage)
+			} else {
+				assert.NoError(t, cfg.Validate())
+				assert.Equal(t, tt.expected, cfg)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -26,20 +27,28 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterMetricProcessor struct {
-	cfg              *Config
-	include          filtermetric.Matcher
-	includeAttribute filtermatcher.AttributesMatcher
-	exclude          filtermetric.Matcher
-	excludeAttribute filtermatcher.AttributesMatcher
-	logger           *zap.Logger
-	checksMetrics    bool
-	checksResouces   bool
+	cfg                 *Config
+	include             filtermetric.Matcher
+	includeAttribute    filtermatcher.AttributesMatcher
+	exclude             filtermetric.Matcher
+	excludeAttribute    filtermatcher.AttributesMatcher
+	metricConditions    []tql.Condition
+	dataPointConditions []tql.Condition
+	logger              *zap.Logger
+	checksMetrics       bool
+	checksResouces      bool
 }
 
 func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	if cfg.Metrics.hasConditions() {
+		return newTQLFilterMetricProcessor(logger, cfg)
+	}
 
 	inc, includeAttr, err := createMatcher(cfg.Metrics.Include)
 	if err != nil {
@@ -102,6 +111,30 @@ func newFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricPro
 	}, nil
 }
 
+func newTQLFilterMetricProcessor(logger *zap.Logger, cfg *Config) (*filterMetricProcessor, error) {
+	metricConditions, err := metricParser.ParseConditions(cfg.Metrics.MetricConditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metric conditions: %w", err)
+	}
+	dataPointConditions, err := dataPointParser.ParseConditions(cfg.Metrics.DataPointConditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data point conditions: %w", err)
+	}
+
+	logger.Info(
+		"Metric filter configured",
+		zap.Strings("metric conditions", cfg.Metrics.MetricConditions),
+		zap.Strings("data point conditions", cfg.Metrics.DataPointConditions),
+	)
+
+	return &filterMetricProcessor{
+		cfg:                 cfg,
+		metricConditions:    metricConditions,
+		dataPointConditions: dataPointConditions,
+		logger:              logger,
+	}, nil
+}
+
 func createMatcher(mp *filtermetric.MatchProperties) (filtermetric.Matcher, filtermatcher.AttributesMatcher, error) {
 	// Nothing specified in configuration
 	if mp == nil {
@@ -125,6 +158,14 @@ func createMatcher(mp *filtermetric.MatchProperties) (filtermetric.Matcher, filt
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(_ context.Context, pdm pmetric.Metrics) (pmetric.Metrics, error) {
+	if fmp.metricConditions != nil || fmp.dataPointConditions != nil {
+		fmp.filterMetricsWithConditions(pdm)
+		if pdm.ResourceMetrics().Len() == 0 {
+			return pdm, processorhelper.ErrSkipProcessingData
+		}
+		return pdm, nil
+	}
+
 	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
 		keepMetricsForResource := fmp.shouldKeepMetricsForResource(rm.Resource())
 		if !keepMetricsForResource {
@@ -200,3 +241,77 @@ func (fmp *filterMetricProcessor) shouldKeepMetricsForResource(resource pcommon.
 
 	return true
 }
+
+// filterMetricsWithConditions removes the metrics for which any of the metric conditions
+// is true, then the data points for which any of the data point conditions is true. Metrics
+// whose data points are all removed are removed too.
+func (fmp *filterMetricProcessor) filterMetricsWithConditions(pdm pmetric.Metrics) {
+	pdm.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resource := rm.Resource()
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scope := sm.Scope()
+			metrics := sm.Metrics()
+			metrics.RemoveIf(func(m pmetric.Metric) bool {
+				if matchesAny(fmp.metricConditions, tqlmetric.NewTransformContext(m, metrics, scope, resource)) {
+					return true
+				}
+				if len(fmp.dataPointConditions) == 0 {
+					return false
+				}
+				return fmp.removeDataPoints(m, metrics, scope, resource)
+			})
+			// Filter out empty ScopeMetrics
+			return metrics.Len() == 0
+		})
+		// Filter out empty ResourceMetrics
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// removeDataPoints removes the data points of the metric for which any of the data point
+// conditions is true, and returns true if the metric had data points and all of them were
+// removed.
+func (fmp *filterMetricProcessor) removeDataPoints(m pmetric.Metric, metrics pmetric.MetricSlice, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	matches := func(dp interface{}) bool {
+		return matchesAny(fmp.dataPointConditions, tqlmetrics.NewTransformContext(dp, m, metrics, scope, resource))
+	}
+
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return matches(dp) })
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return matches(dp) })
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return matches(dp) })
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return matches(dp) })
+		return dps.Len() == 0
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		if dps.Len() == 0 {
+			return false
+		}
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return matches(dp) })
+		return dps.Len() == 0
+	}
+	return false
+}
@@ -23,16 +23,31 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterLogProcessor struct {
-	cfg            *Config
-	excludeMatcher filterlog.Matcher
-	includeMatcher filterlog.Matcher
-	logger         *zap.Logger
+	cfg                 *Config
+	excludeMatcher      filterlog.Matcher
+	includeMatcher      filterlog.Matcher
+	logRecordConditions []tql.Condition
+	logger              *zap.Logger
 }
 
 func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	if len(cfg.Logs.LogRecordConditions) > 0 {
+		logRecordConditions, err := logRecordParser.ParseConditions(cfg.Logs.LogRecordConditions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log record conditions: %w", err)
+		}
+		return &filterLogProcessor{
+			cfg:                 cfg,
+			logRecordConditions: logRecordConditions,
+			logger:              logger,
+		}, nil
+	}
+
 	var includeMatcher filterlog.Matcher
 	var excludeMatcher filterlog.Matcher
 
@@ -97,6 +112,13 @@ func (flp *filterLogProcessor) filterLogRecords(rLogs plog.ResourceLogsSlice) {
 					return flp.excludeMatcher.MatchLogRecord(lr, resource, instrumentationScope)
 				})
 			}
+
+			if len(flp.logRecordConditions) > 0 {
+				// Remove all records for which any of the conditions is true.
+				lrs.RemoveIf(func(lr plog.LogRecord) bool {
+					return matchesAny(flp.logRecordConditions, tqllogs.NewTransformContext(lr, instrumentationScope, resource))
+				})
+			}
 		}
 
 		scopes.RemoveIf(func(sl plog.ScopeLogs) bool {
@@ -623,6 +623,85 @@ func TestFilterLogProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterLogProcessorWithTQL(t *testing.T) {
+	tests := []struct {
+		name            string
+		conditions      []string
+		outLN           [][]string // output Log names per Resource
+		allLogsFiltered bool
+	}{
+		{
+			name:       "drop log records by attribute",
+			conditions: []string{`attributes["name"] == "log2"`, `attributes["name"] == "log3"`},
+			outLN: [][]string{
+				{"log1"},
+				{"log4"},
+			},
+		},
+		{
+			name:       "drop log records by resource attribute",
+			conditions: []string{`resource.attributes["attr1"] == "attr1/val2"`},
+			outLN: [][]string{
+				{"log1", "log2"},
+			},
+		},
+		{
+			name:            "drop all log records",
+			conditions:      []string{`IsMatch(attributes["name"], "log.*") == true`},
+			allLogsFiltered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// next stores the results of the filter log processor
+			next := new(consumertest.LogsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs: LogFilters{
+					LogRecordConditions: test.conditions,
+				},
+			}
+			factory := NewFactory()
+			flp, err := factory.CreateLogsProcessor(
+				context.Background(),
+				componenttest.NewNopProcessorCreateSettings(),
+				cfg,
+				next,
+			)
+			require.NotNil(t, flp)
+			require.Nil(t, err)
+
+			ctx := context.Background()
+			require.NoError(t, flp.Start(ctx, nil))
+
+			cErr := flp.ConsumeLogs(ctx, testResourceLogs(inLogForTwoResource))
+			assert.Nil(t, cErr)
+			got := next.AllLogs()
+
+			if test.allLogsFiltered {
+				require.Len(t, got, 0)
+				return
+			}
+
+			require.Len(t, got, 1)
+			rLogs := got[0].ResourceLogs()
+			assert.Equal(t, len(test.outLN), rLogs.Len())
+
+			for i, wantOut := range test.outLN {
+				gotLogs := rLogs.At(i).ScopeLogs().At(0).LogRecords()
+				assert.Equal(t, len(wantOut), gotLogs.Len())
+				for idx := range wantOut {
+					val, ok := gotLogs.At(idx).Attributes().Get("name")
+					require.True(t, ok)
+					assert.Equal(t, wantOut[idx], val.AsString())
+				}
+			}
+			assert.NoError(t, flp.Shutdown(ctx))
+		})
+	}
+}
+
 func testResourceLogs(lwrs []logWithResource) plog.Logs {
 	ld := plog.NewLogs()
 
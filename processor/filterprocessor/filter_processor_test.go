@@ -379,6 +379,126 @@ func TestFilterMetricProcessor(t *testing.T) {
 	}
 }
 
+func TestFilterMetricProcessorWithTQL(t *testing.T) {
+	tests := []struct {
+		name                string
+		metricConditions    []string
+		dataPointConditions []string
+		outMN               [][]string // output Metric names per Resource
+		outDataPoints       int        // output data points per Metric
+		allMetricsFiltered  bool
+	}{
+		{
+			name:             "drop metrics by name",
+			metricConditions: []string{`name == "metric1"`, `name == "metric4"`},
+			outMN: [][]string{
+				{"metric2"},
+				{"metric3"},
+			},
+			outDataPoints: 2,
+		},
+		{
+			name:             "drop metrics by resource attribute",
+			metricConditions: []string{`resource.attributes["attr1"] == "attr1/val1"`},
+			outMN: [][]string{
+				{"metric3", "metric4"},
+			},
+			outDataPoints: 2,
+		},
+		{
+			name:               "drop all metrics",
+			metricConditions:   []string{`IsMatch(name, "metric.*") == true`},
+			allMetricsFiltered: true,
+		},
+		{
+			name:                "drop data points",
+			dataPointConditions: []string{`attributes["test"] == "pass"`},
+			outMN: [][]string{
+				{"metric1", "metric2"},
+				{"metric3", "metric4"},
+			},
+			outDataPoints: 1,
+		},
+		{
+			name:                "drop metrics without data points left",
+			dataPointConditions: []string{`attributes["test"] == "pass"`, `value_double == 123.0 and metric.name == "metric2"`},
+			outMN: [][]string{
+				{"metric1"},
+				{"metric3", "metric4"},
+			},
+			outDataPoints: 1,
+		},
+		{
+			name:                "drop metrics and data points",
+			metricConditions:    []string{`name == "metric1"`},
+			dataPointConditions: []string{`attributes["test"] == "pass"`},
+			outMN: [][]string{
+				{"metric2"},
+				{"metric3", "metric4"},
+			},
+			outDataPoints: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// next stores the results of the filter metric processor
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Metrics: MetricFilters{
+					MetricConditions:    test.metricConditions,
+					DataPointConditions: test.dataPointConditions,
+				},
+			}
+			factory := NewFactory()
+			fmp, err := factory.CreateMetricsProcessor(
+				context.Background(),
+				componenttest.NewNopProcessorCreateSettings(),
+				cfg,
+				next,
+			)
+			require.NotNil(t, fmp)
+			require.Nil(t, err)
+
+			ctx := context.Background()
+			require.NoError(t, fmp.Start(ctx, nil))
+
+			// Every metric gets a second data point, which the data point conditions match
+			md := testResourceMetrics(inMetricForTwoResource)
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				ms := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+				for j := 0; j < ms.Len(); j++ {
+					dp := ms.At(j).Gauge().DataPoints().AppendEmpty()
+					dp.SetDoubleVal(456)
+					dp.Attributes().PutString("test", "pass")
+				}
+			}
+
+			cErr := fmp.ConsumeMetrics(ctx, md)
+			assert.Nil(t, cErr)
+			got := next.AllMetrics()
+
+			if test.allMetricsFiltered {
+				require.Equal(t, 0, len(got))
+				return
+			}
+
+			require.Equal(t, 1, len(got))
+			require.Equal(t, len(test.outMN), got[0].ResourceMetrics().Len())
+			for i, wantOut := range test.outMN {
+				gotMetrics := got[0].ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+				assert.Equal(t, len(wantOut), gotMetrics.Len())
+				for idx := range wantOut {
+					assert.Equal(t, wantOut[idx], gotMetrics.At(idx).Name())
+					assert.Equal(t, test.outDataPoints, gotMetrics.At(idx).Gauge().DataPoints().Len())
+				}
+			}
+			assert.NoError(t, fmp.Shutdown(ctx))
+		})
+	}
+}
+
 func testResourceMetrics(mwrs []metricWithResource) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -16,6 +16,7 @@ package filterprocessor // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -23,16 +24,25 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 type filterSpanProcessor struct {
-	cfg     *Config
-	include filterspan.Matcher
-	exclude filterspan.Matcher
-	logger  *zap.Logger
+	cfg                 *Config
+	include             filterspan.Matcher
+	exclude             filterspan.Matcher
+	spanConditions      []tql.Condition
+	spanEventConditions []tql.Condition
+	logger              *zap.Logger
 }
 
 func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	if cfg.Traces.hasConditions() {
+		return newTQLFilterSpansProcessor(logger, cfg)
+	}
+
 	if cfg.Spans.Include == nil && cfg.Spans.Exclude == nil {
 		return nil, nil
 	}
@@ -66,6 +76,31 @@ func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProces
 	}, nil
 }
 
+func newTQLFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	spanConditions, err := spanParser.ParseConditions(cfg.Traces.SpanConditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse span conditions: %w", err)
+	}
+	spanEventConditions, err := spanEventParser.ParseConditions(cfg.Traces.SpanEventConditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse span event conditions: %w", err)
+	}
+
+	logger.Info(
+		"Span filter configured",
+		zap.String("ID", cfg.ID().String()),
+		zap.Strings("span conditions", cfg.Traces.SpanConditions),
+		zap.Strings("span event conditions", cfg.Traces.SpanEventConditions),
+	)
+
+	return &filterSpanProcessor{
+		cfg:                 cfg,
+		spanConditions:      spanConditions,
+		spanEventConditions: spanEventConditions,
+		logger:              logger,
+	}, nil
+}
+
 func createSpanMatcher(cfg *Config) (filterspan.Matcher, filterspan.Matcher, error) {
 	var includeMatcher filterspan.Matcher
 	var excludeMatcher filterspan.Matcher
@@ -94,6 +129,11 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 			ils.Spans().RemoveIf(func(span ptrace.Span) bool {
 				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope())
 			})
+			if len(fsp.spanEventConditions) > 0 {
+				for y := 0; y < ils.Spans().Len(); y++ {
+					fsp.removeSpanEvents(ils.Spans().At(y), resSpan.Resource(), ils.Scope())
+				}
+			}
 		}
 		// Remove empty elements, that way if we delete everything we can tell
 		// the pipeline to stop processing completely (ErrSkipProcessingData)
@@ -123,5 +163,16 @@ func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcom
 		}
 	}
 
+	if len(fsp.spanConditions) > 0 {
+		return matchesAny(fsp.spanConditions, tqltraces.NewTransformContext(span, library, resource))
+	}
+
 	return false
 }
+
+// removeSpanEvents removes the events of the span that match any of the span event conditions.
+func (fsp *filterSpanProcessor) removeSpanEvents(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) {
+	span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+		return matchesAny(fsp.spanEventConditions, tqlspanevents.NewTransformContext(event, span, library, resource))
+	})
+}
@@ -179,3 +179,100 @@ func generateTraces(traces []testTrace) ptrace.Traces {
 	}
 	return td
 }
+
+func TestFilterTraceProcessorWithTQL(t *testing.T) {
+	tests := []struct {
+		name                string
+		spanConditions      []string
+		spanEventConditions []string
+		allTracesFiltered   bool
+		spanCountExpected   int
+		eventCountExpected  int
+	}{
+		{
+			name:               "drop spans by resource attribute",
+			spanConditions:     []string{`resource.attributes["service.name"] == "dont_keep"`},
+			spanCountExpected:  2,
+			eventCountExpected: 4,
+		},
+		{
+			name:              "drop all spans",
+			spanConditions:    []string{`name == "test!"`},
+			allTracesFiltered: true,
+		},
+		{
+			name: "drop spans with any condition",
+			spanConditions: []string{
+				`resource.attributes["service.name"] == "dont_keep"`,
+				`resource.attributes["service.name"] == "keep"`,
+			},
+			allTracesFiltered: true,
+		},
+		{
+			name:                "drop span events",
+			spanEventConditions: []string{`name == "drop"`},
+			spanCountExpected:   3,
+			eventCountExpected:  3,
+		},
+		{
+			name:                "drop spans and span events",
+			spanConditions:      []string{`resource.attributes["service.name"] == "dont_keep"`},
+			spanEventConditions: []string{`name == "drop" and resource.attributes["service.name"] == "keep"`},
+			spanCountExpected:   2,
+			eventCountExpected:  2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := new(consumertest.TracesSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Traces: TraceFilters{
+					SpanConditions:      test.spanConditions,
+					SpanEventConditions: test.spanEventConditions,
+				},
+			}
+			factory := NewFactory()
+			fmp, err := factory.CreateTracesProcessor(
+				ctx,
+				componenttest.NewNopProcessorCreateSettings(),
+				cfg,
+				next,
+			)
+			require.NotNil(t, fmp)
+			require.Nil(t, err)
+
+			require.NoError(t, fmp.Start(ctx, nil))
+
+			// Every span gets a span event to keep and another one to drop
+			td := generateTraces(nameTraces)
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				span := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans().At(0)
+				span.Events().AppendEmpty().SetName("keep")
+				span.Events().AppendEmpty().SetName("drop")
+			}
+
+			cErr := fmp.ConsumeTraces(ctx, td)
+			require.Nil(t, cErr)
+			got := next.AllTraces()
+
+			// If all traces got filtered you shouldn't even have ResourceSpans
+			if test.allTracesFiltered {
+				require.Equal(t, 0, len(got))
+			} else {
+				require.Equal(t, test.spanCountExpected, got[0].SpanCount())
+				eventCount := 0
+				for i := 0; i < got[0].ResourceSpans().Len(); i++ {
+					spans := got[0].ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+					for j := 0; j < spans.Len(); j++ {
+						eventCount += spans.At(j).Events().Len()
+					}
+				}
+				require.Equal(t, test.eventCountExpected, eventCount)
+			}
+			require.NoError(t, fmp.Shutdown(ctx))
+		})
+	}
+}
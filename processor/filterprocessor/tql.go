@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// tqlFunctions are the functions that can be used within conditions. Functions
+// that modify telemetry are left out on purpose, since conditions must not
+// change the telemetry they evaluate.
+var tqlFunctions = map[string]interface{}{
+	"TraceID":         tqlotel.TraceID,
+	"SpanID":          tqlotel.SpanID,
+	"IsMatch":         tqlcommon.IsMatch,
+	"Concat":          tqlcommon.Concat,
+	"Split":           tqlotel.Split,
+	"Int":             tqlcommon.Int,
+	"Double":          tqlcommon.Double,
+	"String":          tqlcommon.String,
+	"ConvertCase":     tqlcommon.ConvertCase,
+	"Substring":       tqlcommon.Substring,
+	"SHA1":            tqlcommon.SHA1,
+	"SHA256":          tqlcommon.SHA256,
+	"FNV":             tqlcommon.FNV,
+	"Time":            tqlcommon.Time,
+	"UnixNano":        tqlcommon.UnixNano,
+	"ParseJSON":       tqlcommon.ParseJSON,
+	"ParseKeyValue":   tqlcommon.ParseKeyValue,
+	"ExtractPatterns": tqlcommon.ExtractPatterns,
+}
+
+var (
+	spanParser      = tql.NewParser(tqlFunctions, tqltraces.ParsePath, tqltraces.ParseEnum, tql.NoOpLogger{})
+	spanEventParser = tql.NewParser(tqlFunctions, tqlspanevents.ParsePath, tqlspanevents.ParseEnum, tql.NoOpLogger{})
+	metricParser    = tql.NewParser(tqlFunctions, tqlmetric.ParsePath, tqlmetric.ParseEnum, tql.NoOpLogger{})
+	dataPointParser = tql.NewParser(tqlFunctions, tqlmetrics.ParsePath, tqlmetrics.ParseEnum, tql.NoOpLogger{})
+	logRecordParser = tql.NewParser(tqlFunctions, tqllogs.ParsePath, tqllogs.ParseEnum, tql.NoOpLogger{})
+)
+
+// matchesAny returns true if any of the conditions is true in the context.
+func matchesAny(conditions []tql.Condition, ctx tql.TransformContext) bool {
+	for _, condition := range conditions {
+		if condition(ctx) {
+			return true
+		}
+	}
+	return false
+}
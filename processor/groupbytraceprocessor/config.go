@@ -46,5 +46,11 @@ type Config struct {
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
+	// Use StorageID to keep traces on disk instead.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of a storage extension to keep the traces in, instead of keeping them in memory.
+	// Traces kept in the storage are recovered when the processor starts again.
+	// Default: none, traces are kept in memory.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
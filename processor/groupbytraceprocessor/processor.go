@@ -93,14 +93,51 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+
+	if rs, ok := sp.st.(recoverableStorage); ok {
+		sp.recoverTraces(rs)
+	}
+	return nil
+}
+
+// recoverTraces takes the traces that were kept in the storage before the last
+// shutdown out of it, and consumes them again, so that they are released once
+// the wait duration elapsed.
+func (sp *groupByTraceProcessor) recoverTraces(rs recoverableStorage) {
+	traceIDs := rs.recoveredTraces()
+	for _, traceID := range traceIDs {
+		rss, err := rs.delete(traceID)
+		if err != nil {
+			sp.logger.Warn("couldn't recover trace from the storage",
+				zap.String("traceID", traceID.HexString()), zap.Error(err))
+			continue
+		}
+		if rss == nil {
+			continue
+		}
+
+		trace := ptrace.NewTraces()
+		for _, resourceSpans := range rss {
+			resourceSpans.MoveTo(trace.ResourceSpans().AppendEmpty())
+		}
+		if err = sp.eventMachine.consume(trace); err != nil {
+			sp.logger.Warn("couldn't recover trace from the storage",
+				zap.String("traceID", traceID.HexString()), zap.Error(err))
+		}
+	}
+	if len(traceIDs) > 0 {
+		sp.logger.Info("recovered traces from the storage", zap.Int("traces", len(traceIDs)))
+	}
 }
 
 // Shutdown is invoked during service shutdown.
@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// traceIDsKey is the key of the list of trace IDs held by the storage.
+	traceIDsKey = "trace_ids"
+	// traceIDEntrySize is the size of an entry of the list of trace IDs: the
+	// trace ID followed by the number of batches stored for the trace.
+	traceIDEntrySize = 16 + 4
+)
+
+var (
+	tracesMarshaler   = ptrace.NewProtoMarshaler()
+	tracesUnmarshaler = ptrace.NewProtoUnmarshaler()
+
+	errInvalidTraceIDs = errors.New("invalid list of trace IDs in storage")
+	errStorageClosed   = errors.New("the storage is not started or already shut down")
+)
+
+// extensionStorage keeps the traces in a storage extension, such as the file
+// storage, so that only their IDs are kept in memory and the traces survive
+// restarts. Each batch of spans of a trace is stored under its own key, so that
+// appending to a trace does not rewrite the spans already stored. The list of
+// trace IDs, along with the number of batches of each trace, is written to the
+// storage periodically and on shutdown, so that the traces can be recovered
+// when the processor starts again.
+type extensionStorage struct {
+	storageID   config.ComponentID
+	componentID config.ComponentID
+	logger      *zap.Logger
+	client      extstorage.Client
+
+	sync.RWMutex
+	traceIDs  map[pcommon.TraceID]uint32
+	recovered []pcommon.TraceID
+	dirty     bool
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ storage = (*extensionStorage)(nil)
+var _ recoverableStorage = (*extensionStorage)(nil)
+
+func newExtensionStorage(logger *zap.Logger, storageID config.ComponentID, componentID config.ComponentID) *extensionStorage {
+	return &extensionStorage{
+		storageID:                 storageID,
+		componentID:               componentID,
+		logger:                    logger,
+		traceIDs:                  make(map[pcommon.TraceID]uint32),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func batchKey(traceID pcommon.TraceID, n uint32) string {
+	return "trace_" + traceID.HexString() + "_" + strconv.FormatUint(uint64(n), 10)
+}
+
+// createOrAppend stores the spans as a new batch of the trace. Operations on a
+// single trace are serialized by the event machine, so there is no concurrent
+// write of the same key.
+func (st *extensionStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	buf, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	st.RLock()
+	client, n := st.client, st.traceIDs[traceID]
+	st.RUnlock()
+	if client == nil {
+		return errStorageClosed
+	}
+
+	if err = client.Set(context.Background(), batchKey(traceID, n), buf); err != nil {
+		return err
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	st.traceIDs[traceID] = n + 1
+	st.dirty = true
+	return nil
+}
+
+func (st *extensionStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.RLock()
+	client, numBatches := st.client, st.traceIDs[traceID]
+	st.RUnlock()
+
+	gets := batchGetOperations(traceID, numBatches)
+	if len(gets) == 0 {
+		return nil, nil
+	}
+	if client == nil {
+		return nil, errStorageClosed
+	}
+	if err := client.Batch(context.Background(), gets...); err != nil {
+		return nil, err
+	}
+	return unmarshalResourceSpans(gets)
+}
+
+func (st *extensionStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	client := st.client
+	if client == nil {
+		st.Unlock()
+		return nil, errStorageClosed
+	}
+	numBatches, ok := st.traceIDs[traceID]
+	if ok {
+		delete(st.traceIDs, traceID)
+		st.dirty = true
+	}
+	st.Unlock()
+
+	gets := batchGetOperations(traceID, numBatches)
+	if len(gets) == 0 {
+		return nil, nil
+	}
+	ops := make([]extstorage.Operation, 0, 2*len(gets))
+	ops = append(ops, gets...)
+	for n := uint32(0); n < numBatches; n++ {
+		ops = append(ops, extstorage.DeleteOperation(batchKey(traceID, n)))
+	}
+	if err := client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+	return unmarshalResourceSpans(gets)
+}
+
+func (st *extensionStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(extstorage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return err
+	}
+	st.Lock()
+	st.client = client
+	st.Unlock()
+
+	buf, err := client.Get(ctx, traceIDsKey)
+	if err != nil {
+		return err
+	}
+	if len(buf)%traceIDEntrySize != 0 {
+		return errInvalidTraceIDs
+	}
+	st.Lock()
+	for ; len(buf) > 0; buf = buf[traceIDEntrySize:] {
+		var traceID pcommon.TraceID
+		copy(traceID[:], buf[:16])
+		st.traceIDs[traceID] = binary.BigEndian.Uint32(buf[16:traceIDEntrySize])
+		st.recovered = append(st.recovered, traceID)
+	}
+	st.Unlock()
+
+	go st.periodicFlush()
+	return nil
+}
+
+func (st *extensionStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return nil
+	}
+	ctx := context.Background()
+	err := st.flushLocked(ctx)
+	if closeErr := st.client.Close(ctx); err == nil {
+		err = closeErr
+	}
+	st.client = nil
+	return err
+}
+
+// recoveredTraces returns the IDs of the traces that were in the storage when it
+// was started.
+func (st *extensionStorage) recoveredTraces() []pcommon.TraceID {
+	st.RLock()
+	defer st.RUnlock()
+	return st.recovered
+}
+
+// flush writes the list of trace IDs to the storage, if it changed since the
+// last flush.
+func (st *extensionStorage) flush(ctx context.Context) error {
+	st.Lock()
+	defer st.Unlock()
+	return st.flushLocked(ctx)
+}
+
+func (st *extensionStorage) flushLocked(ctx context.Context) error {
+	if !st.dirty || st.client == nil {
+		return nil
+	}
+
+	buf := make([]byte, len(st.traceIDs)*traceIDEntrySize)
+	entry := buf
+	for traceID, numBatches := range st.traceIDs {
+		copy(entry[:16], traceID[:])
+		binary.BigEndian.PutUint32(entry[16:traceIDEntrySize], numBatches)
+		entry = entry[traceIDEntrySize:]
+	}
+	if err := st.client.Set(ctx, traceIDsKey, buf); err != nil {
+		return err
+	}
+	st.dirty = false
+	return nil
+}
+
+func (st *extensionStorage) periodicFlush() {
+	if err := st.flush(context.Background()); err != nil {
+		st.logger.Warn("failed to write the trace IDs to the storage", zap.Error(err))
+	}
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(st.count())))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicFlush()
+	})
+}
+
+func (st *extensionStorage) count() int {
+	st.RLock()
+	defer st.RUnlock()
+	return len(st.traceIDs)
+}
+
+// batchGetOperations returns the operations reading the batches of the trace.
+func batchGetOperations(traceID pcommon.TraceID, numBatches uint32) []extstorage.Operation {
+	ops := make([]extstorage.Operation, numBatches)
+	for n := uint32(0); n < numBatches; n++ {
+		ops[n] = extstorage.GetOperation(batchKey(traceID, n))
+	}
+	return ops
+}
+
+// unmarshalResourceSpans returns the resource spans of the batches read by the
+// operations, in the order in which the batches were stored.
+func unmarshalResourceSpans(gets []extstorage.Operation) ([]ptrace.ResourceSpans, error) {
+	var result []ptrace.ResourceSpans
+	for _, op := range gets {
+		if op.Value == nil {
+			continue
+		}
+		td, err := tracesUnmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			result = append(result, td.ResourceSpans().At(i))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newStartedExtensionStorage(t *testing.T) *extensionStorage {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	st := newExtensionStorage(zap.NewNop(), ext.ID(), config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, st.shutdown())
+	})
+	return st
+}
+
+func TestExtensionCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newStartedExtensionStorage(t)
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		expected := simpleTracesWithID(traceID)
+
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, []ptrace.ResourceSpans{expected.ResourceSpans().At(0)}, retrieved)
+	}
+
+	retrieved, err := st.get(pcommon.TraceID([16]byte{9}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestExtensionDeleteTrace(t *testing.T) {
+	// prepare
+	st := newStartedExtensionStorage(t)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	assert.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestExtensionAppendSpans(t *testing.T) {
+	// prepare
+	st := newStartedExtensionStorage(t)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	first.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("first-name")
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second-name")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, "first-name", retrieved[0].ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "second-name", retrieved[1].ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, 1, st.count())
+
+	// each batch is stored under its own key
+	for n, name := range []string{"first-name", "second-name"} {
+		buf, err := st.client.Get(context.Background(), batchKey(traceID, uint32(n)))
+		require.NoError(t, err)
+		td, err := tracesUnmarshaler.UnmarshalTraces(buf)
+		require.NoError(t, err)
+		assert.Equal(t, name, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	}
+
+	// deleting the trace removes all of its batches
+	deleted, err := st.delete(traceID)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+	for n := uint32(0); n < 2; n++ {
+		buf, err := st.client.Get(context.Background(), batchKey(traceID, n))
+		require.NoError(t, err)
+		assert.Nil(t, buf)
+	}
+}
+
+func TestExtensionStartErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		storageID config.ComponentID
+		host      component.Host
+	}{
+		{
+			name:      "missing",
+			storageID: storagetest.NewStorageID("missing"),
+			host:      storagetest.NewStorageHost().WithInMemoryStorageExtension("test"),
+		},
+		{
+			name:      "non_storage",
+			storageID: storagetest.NewNonStorageID("test"),
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newExtensionStorage(zap.NewNop(), tt.storageID, config.NewComponentID(typeStr))
+			assert.Error(t, st.start(context.Background(), tt.host))
+			assert.NoError(t, st.shutdown())
+		})
+	}
+}
+
+func TestExtensionClosed(t *testing.T) {
+	// prepare
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	st := newExtensionStorage(zap.NewNop(), ext.ID(), config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	require.NoError(t, st.shutdown())
+
+	// verify
+	assert.ErrorIs(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)), errStorageClosed)
+	_, err := st.get(traceID)
+	assert.ErrorIs(t, err, errStorageClosed)
+	_, err = st.delete(traceID)
+	assert.ErrorIs(t, err, errStorageClosed)
+}
+
+func TestExtensionInvalidTraceIDs(t *testing.T) {
+	// prepare
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	client, err := ext.GetClient(context.Background(), component.KindProcessor, config.NewComponentID(typeStr), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), traceIDsKey, []byte{1, 2, 3}))
+	require.NoError(t, client.Close(context.Background()))
+
+	// test
+	st := newExtensionStorage(zap.NewNop(), ext.ID(), config.NewComponentID(typeStr))
+	err = st.start(context.Background(), host)
+
+	// verify
+	assert.ErrorIs(t, err, errInvalidTraceIDs)
+	assert.NoError(t, st.shutdown())
+}
+
+func TestExtensionBatchesAreRecovered(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	st := newExtensionStorage(zap.NewNop(), ext.ID(), config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.shutdown())
+
+	// test, start again on the same storage, as after a restart
+	ext = storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host = storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	st = newExtensionStorage(zap.NewNop(), ext.ID(), config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{traceID}, st.recoveredTraces())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 3)
+}
+
+func TestExtensionTracesAreRecovered(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		WaitDuration:      time.Hour,
+		NumTraces:         10,
+		NumWorkers:        1,
+	}
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+
+	st := newExtensionStorage(zap.NewNop(), ext.ID(), cfg.ID())
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, cfg)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, host))
+	require.NoError(t, p.ConsumeTraces(ctx, trace))
+	// the event machine doesn't wait for the event being processed on shutdown
+	require.Eventually(t, func() bool {
+		return st.count() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(ctx))
+
+	// test, start again on the same storage, as after a restart
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, trace, received)
+			wg.Done()
+			return nil
+		},
+	}
+	cfg.WaitDuration = time.Millisecond
+	ext = storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host = storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	st = newExtensionStorage(zap.NewNop(), ext.ID(), cfg.ID())
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// verify
+	wg.Wait()
+	assert.Eventually(t, func() bool {
+		return st.count() == 0
+	}, time.Second, 10*time.Millisecond)
+}
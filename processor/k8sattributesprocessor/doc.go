@@ -58,6 +58,9 @@
 //
 // Not all the attributes are guaranteed to be added.
 //
+// The `k8s.node.uid` attribute can be added to the `metadata` list as well. It is taken from the Node the pod
+// is running on, which requires the processor to watch Nodes (see RBAC below).
+//
 // Only attribute names from `metadata` should be used for pod_association's `resource_attribute`,
 // because empty or non-existing values will be ignored.
 //
@@ -70,12 +73,14 @@
 //     require identifier of a particular container run set as `k8s.container.restart_count` in resource attributes:
 //     - container.id
 //
-// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods and namespaces.
-// The config for associating the data passing through the processor (spans, metrics and logs) with specific Pod/Namespace annotations/labels is configured via "annotations"  and "labels" keys.
-// This config represents a list of annotations/labels that are extracted from pods/namespaces and added to spans, metrics and logs.
+// The k8sattributesprocessor can be used for automatic tagging of spans, metrics and logs with k8s labels and annotations from pods, namespaces and nodes.
+// The config for associating the data passing through the processor (spans, metrics and logs) with specific Pod/Namespace/Node annotations/labels is configured via "annotations"  and "labels" keys.
+// This config represents a list of annotations/labels that are extracted from pods/namespaces/nodes and added to spans, metrics and logs.
 // Each item is specified as a config of tag_name (representing the tag name to tag the spans with),
 // key (representing the key used to extract value) and from (representing the kubernetes object used to extract the value).
-// The "from" field has only two possible values "pod" and "namespace" and defaults to "pod" if none is specified.
+// The "from" field has three possible values "pod", "namespace" and "node" and defaults to "pod" if none is specified.
+// Node labels and annotations are taken from the node the pod is running on or, when the pod is not identified,
+// from the node named by the `k8s.node.name` resource attribute.
 //
 // A few examples to use this config are as follows:
 // annotations:
@@ -95,11 +100,15 @@
 //     key: label2
 //     regex: field=(?P<value>.+)
 //     from: pod
+//   - tag_name: l3 # extracts value of label from nodes with key `topology.kubernetes.io/zone` and inserts it as a tag with key `l3`
+//     key: topology.kubernetes.io/zone
+//     from: node
 //
 // # RBAC
 //
 // The k8sattributesprocessor needs `get`, `watch` and `list` permissions on both `pods` and `namespaces` resources, for all namespaces and pods included in the configured filters.
-// Here is an example of a `ClusterRole` to give a `ServiceAccount` the necessary permissions for all pods and namespaces in the cluster (replace `<OTEL_COL_NAMESPACE>` with a namespace where collector is deployed):
+// When node metadata is extracted, with `k8s.node.uid` in `metadata` or labels/annotations `from: node`, the same permissions are needed on `nodes`.
+// Here is an example of a `ClusterRole` to give a `ServiceAccount` the necessary permissions for all pods, namespaces and nodes in the cluster (replace `<OTEL_COL_NAMESPACE>` with a namespace where collector is deployed):
 //
 //	apiVersion: v1
 //	kind: ServiceAccount
@@ -113,7 +122,7 @@
 //	  name: otel-collector
 //	rules:
 //	- apiGroups: [""]
-//	  resources: ["pods", "namespaces"]
+//	  resources: ["pods", "namespaces", "nodes"]
 //	  verbs: ["get", "watch", "list"]
 //	---
 //	apiVersion: rbac.authorization.k8s.io/v1
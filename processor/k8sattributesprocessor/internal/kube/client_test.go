@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -57,6 +58,31 @@ func newPodIdentifier(from string, name string, value string) PodIdentifier {
 	}
 }
 
+func nodeAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj interface{})) {
+	assert.Equal(t, len(c.Nodes), 0)
+
+	node := &api_v1.Node{}
+	handler(node)
+	assert.Equal(t, len(c.Nodes), 0)
+
+	node = &api_v1.Node{}
+	node.Name = "nodeA"
+	handler(node)
+	assert.Equal(t, len(c.Nodes), 1)
+	got := c.Nodes["nodeA"]
+	assert.Equal(t, got.Name, "nodeA")
+	assert.Equal(t, got.NodeUID, "")
+
+	node = &api_v1.Node{}
+	node.Name = "nodeB"
+	node.UID = "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+	handler(node)
+	assert.Equal(t, len(c.Nodes), 2)
+	got = c.Nodes["nodeB"]
+	assert.Equal(t, got.Name, "nodeB")
+	assert.Equal(t, got.NodeUID, "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+}
+
 func podAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj interface{})) {
 	assert.Equal(t, len(c.Pods), 0)
 
@@ -128,12 +154,12 @@ func namespaceAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj in
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -149,6 +175,7 @@ func TestBadFilters(t *testing.T) {
 		newFakeAPIClientset,
 		NewFakeInformer,
 		NewFakeNamespaceInformer,
+		NewFakeNodeInformer,
 	)
 	assert.Error(t, err)
 	assert.Nil(t, c)
@@ -185,7 +212,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, NewFakeNodeInformer)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "error creating k8s client")
@@ -203,6 +230,11 @@ func TestNamespaceAdd(t *testing.T) {
 	namespaceAddAndUpdateTest(t, c, c.handleNamespaceAdd)
 }
 
+func TestNodeAdd(t *testing.T) {
+	c, _ := newTestClient(t)
+	nodeAddAndUpdateTest(t, c, c.handleNodeAdd)
+}
+
 func TestPodHostNetwork(t *testing.T) {
 	c, _ := newTestClient(t)
 	assert.Equal(t, 0, len(c.Pods))
@@ -334,6 +366,14 @@ func TestNamespaceUpdate(t *testing.T) {
 	})
 }
 
+func TestNodeUpdate(t *testing.T) {
+	c, _ := newTestClient(t)
+	nodeAddAndUpdateTest(t, c, func(obj interface{}) {
+		// first argument (old node) is not used right now
+		c.handleNodeUpdate(&api_v1.Node{}, obj)
+	})
+}
+
 func TestPodDelete(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, c.handlePodAdd)
@@ -417,6 +457,32 @@ func TestNamespaceDelete(t *testing.T) {
 	assert.Equal(t, got.Name, "namespaceA")
 }
 
+func TestNodeDelete(t *testing.T) {
+	c, _ := newTestClient(t)
+	nodeAddAndUpdateTest(t, c, c.handleNodeAdd)
+	assert.Equal(t, len(c.Nodes), 2)
+
+	// delete non-existent node
+	node := &api_v1.Node{}
+	node.Name = "nodeC"
+	c.handleNodeDelete(node)
+	assert.Equal(t, len(c.Nodes), 2)
+
+	// delete node
+	node = &api_v1.Node{}
+	node.Name = "nodeA"
+	c.handleNodeDelete(node)
+	assert.Equal(t, len(c.Nodes), 1)
+	_, ok := c.GetNode("nodeA")
+	assert.False(t, ok)
+
+	// delete node with final state unknown
+	node = &api_v1.Node{}
+	node.Name = "nodeB"
+	c.handleNodeDelete(cache.DeletedFinalStateUnknown{Obj: node})
+	assert.Equal(t, len(c.Nodes), 0)
+}
+
 func TestDeleteQueue(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, c.handlePodAdd)
@@ -878,6 +944,119 @@ func TestNamespaceExtractionRules(t *testing.T) {
 	}
 }
 
+func TestNodeExtractionRules(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+
+	node := &api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "k8s-node-example",
+			UID:               "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			CreationTimestamp: meta_v1.Now(),
+			Labels: map[string]string{
+				"label1": "lv1",
+			},
+			Annotations: map[string]string{
+				"annotation1": "av1",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		attributes map[string]string
+	}{{
+		name:       "no-rules",
+		rules:      ExtractionRules{},
+		attributes: nil,
+	}, {
+		name: "uid",
+		rules: ExtractionRules{
+			NodeUID: true,
+		},
+		attributes: map[string]string{
+			"k8s.node.uid": "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		},
+	}, {
+		name: "labels",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"l1": "lv1",
+			"a1": "av1",
+		},
+	}, {
+		name: "pod-and-namespace-rules-ignored",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromPod,
+			}, {
+				Name: "l2",
+				Key:  "label1",
+				From: MetadataFromNamespace,
+			},
+			},
+		},
+		attributes: nil,
+	},
+		{
+			name: "all-labels",
+			rules: ExtractionRules{
+				Labels: []FieldExtractionRule{{
+					KeyRegex: regexp.MustCompile("^(?:la.*)$"),
+					From:     MetadataFromNode,
+				},
+				},
+			},
+			attributes: map[string]string{
+				"k8s.node.labels.label1": "lv1",
+			},
+		},
+		{
+			name: "all-annotations",
+			rules: ExtractionRules{
+				Annotations: []FieldExtractionRule{{
+					KeyRegex: regexp.MustCompile("^(?:an.*)$"),
+					From:     MetadataFromNode,
+				},
+				},
+			},
+			attributes: map[string]string{
+				"k8s.node.annotations.annotation1": "av1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			c.handleNodeAdd(node)
+			n, ok := c.GetNode(node.Name)
+			require.True(t, ok)
+
+			assert.Equal(t, len(tc.attributes), len(n.Attributes))
+			for k, v := range tc.attributes {
+				got, ok := n.Attributes[k]
+				assert.True(t, ok)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -1363,7 +1542,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 			},
 		},
 	}
-	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, e, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeNodeInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
@@ -1371,3 +1550,62 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 func newTestClient(t *testing.T) (*WatchClient, *observer.ObservedLogs) {
 	return newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
 }
+
+func TestExtractNodeMetadata(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	testCases := []struct {
+		name              string
+		shouldExtractNode bool
+		rules             ExtractionRules
+	}{{
+		name:              "empty-rules",
+		shouldExtractNode: false,
+		rules:             ExtractionRules{},
+	}, {
+		name:              "namespace-rules",
+		shouldExtractNode: false,
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNamespace,
+			},
+			},
+		},
+	}, {
+		name:              "node-uid",
+		shouldExtractNode: true,
+		rules: ExtractionRules{
+			NodeUID: true,
+		},
+	}, {
+		name:              "node-rules-only-annotations",
+		shouldExtractNode: true,
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+	}, {
+		name:              "node-rules-only-labels",
+		shouldExtractNode: true,
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNode,
+			},
+			},
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			assert.Equal(t, tc.shouldExtractNode, c.extractNodeMetadata())
+		})
+	}
+}
@@ -88,6 +88,43 @@ func (f *FakeNamespaceInformer) GetController() cache.Controller {
 	return f.FakeController
 }
 
+type FakeNodeInformer struct {
+	*FakeController
+
+	nodeName string
+}
+
+func NewFakeNodeInformer(
+	_ kubernetes.Interface,
+	nodeName string,
+) cache.SharedInformer {
+	return &FakeNodeInformer{
+		FakeController: &FakeController{},
+		nodeName:       nodeName,
+	}
+}
+
+func (f *FakeNodeInformer) AddEventHandler(handler cache.ResourceEventHandler) {}
+
+func (f *FakeNodeInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, period time.Duration) {
+}
+
+func (f *FakeNodeInformer) SetTransform(handler cache.TransformFunc) error {
+	return nil
+}
+
+func (f *FakeNodeInformer) GetStore() cache.Store {
+	return cache.NewStore(func(obj interface{}) (string, error) { return "", nil })
+}
+
+func (f *FakeNodeInformer) GetController() cache.Controller {
+	return f.FakeController
+}
+
+func (f *FakeNodeInformer) SetWatchErrorHandler(cache.WatchErrorHandler) error {
+	return nil
+}
+
 type FakeController struct {
 	sync.Mutex
 	stopped bool
@@ -44,6 +44,13 @@ func Test_newSharedNamespaceInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newSharedNodeInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newNodeSharedInformer(client, "node1")
+	assert.NotNil(t, informer)
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -81,6 +88,16 @@ func Test_namespaceInformerListFunc(t *testing.T) {
 	assert.NotNil(t, obj)
 }
 
+func Test_nodeInformerListFunc(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	listFunc := nodeInformerListFunc(c, "node1")
+	opts := metav1.ListOptions{}
+	obj, err := listFunc(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, obj)
+}
+
 func Test_informerWatchFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -118,6 +135,16 @@ func Test_namespaceInformerWatchFunc(t *testing.T) {
 	assert.NotNil(t, obj)
 }
 
+func Test_nodeInformerWatchFunc(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	watchFunc := nodeInformerWatchFunc(c, "node1")
+	opts := metav1.ListOptions{}
+	obj, err := watchFunc(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, obj)
+}
+
 func Test_fakeInformer(t *testing.T) {
 	// nothing real to test here. just to make coverage happy
 	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
@@ -141,3 +168,15 @@ func Test_fakeNamespaceInformer(t *testing.T) {
 	store := i.GetStore()
 	assert.NoError(t, store.Add(api_v1.Namespace{}))
 }
+
+func Test_fakeNodeInformer(t *testing.T) {
+	// nothing real to test here. just to make coverage happy
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	i := NewFakeNodeInformer(c, "node1")
+	i.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{}, time.Second)
+	i.HasSynced()
+	i.LastSyncResourceVersion()
+	store := i.GetStore()
+	assert.NoError(t, store.Add(api_v1.Node{}))
+}
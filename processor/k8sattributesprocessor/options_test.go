@@ -131,6 +131,23 @@ func TestWithExtractAnnotations(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.annotations.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -248,6 +265,23 @@ func TestWithExtractLabels(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.labels.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -328,6 +362,13 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.StartTime)
 	assert.False(t, p.rules.Deployment)
 	assert.False(t, p.rules.Node)
+	assert.False(t, p.rules.NodeUID)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, withExtractMetadata(conventions.AttributeK8SNodeName, conventions.AttributeK8SNodeUID)(p))
+	assert.True(t, p.rules.Node)
+	assert.True(t, p.rules.NodeUID)
+	assert.False(t, p.rules.Namespace)
 }
 
 func TestWithFilterLabels(t *testing.T) {
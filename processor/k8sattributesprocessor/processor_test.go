@@ -236,7 +236,7 @@ func TestProcessorBadConfig(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderNode) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -674,6 +674,86 @@ func TestPodUID(t *testing.T) {
 	})
 }
 
+func TestProcessorAddNodeAttributes(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+	)
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "resource_attribute",
+						Name: "k8s.pod.uid",
+					},
+				},
+			},
+		}
+		kp.kc.(*fakeClient).Pods[newPodIdentifier("resource_attribute", "k8s.pod.uid", "ef10d10b-2da5-4030-812e-5f45c1531227")] = &kube.Pod{
+			Name:     "PodA",
+			NodeName: "node-1",
+			Attributes: map[string]string{
+				"k8s.node.name": "node-1",
+			},
+		}
+		kp.kc.(*fakeClient).Nodes["node-1"] = &kube.Node{
+			Name:    "node-1",
+			NodeUID: "11111111-2222-3333-4444-555555555555",
+			Attributes: map[string]string{
+				"k8s.node.uid":         "11111111-2222-3333-4444-555555555555",
+				"k8s.node.labels.zone": "zone-a",
+			},
+		}
+	})
+
+	m.testConsume(context.Background(),
+		generateTraces(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		generateMetrics(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		generateLogs(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		nil)
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(r pcommon.Resource) {
+		assertResourceHasStringAttribute(t, r, "k8s.node.name", "node-1")
+		assertResourceHasStringAttribute(t, r, "k8s.node.uid", "11111111-2222-3333-4444-555555555555")
+		assertResourceHasStringAttribute(t, r, "k8s.node.labels.zone", "zone-a")
+	})
+}
+
+func TestProcessorAddNodeAttributesFromNodeName(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+	)
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.kc.(*fakeClient).Nodes["node-1"] = &kube.Node{
+			Name: "node-1",
+			Attributes: map[string]string{
+				"k8s.node.labels.zone": "zone-a",
+			},
+		}
+	})
+
+	withNodeName := func(res pcommon.Resource) {
+		res.Attributes().PutString(conventions.AttributeK8SNodeName, "node-1")
+	}
+	m.testConsume(context.Background(),
+		generateTraces(withNodeName),
+		generateMetrics(withNodeName),
+		generateLogs(withNodeName),
+		nil)
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(r pcommon.Resource) {
+		assertResourceHasStringAttribute(t, r, "k8s.node.labels.zone", "zone-a")
+	})
+}
+
 func TestProcessorAddLabels(t *testing.T) {
 	m := newMultiTest(
 		t,
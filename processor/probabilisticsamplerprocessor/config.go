@@ -18,7 +18,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 )
 
-// Config has the configuration guiding the trace sampler processor.
+// Config has the configuration guiding the sampler processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
@@ -30,6 +30,11 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// FromAttribute is the name of a log record attribute, e.g. a request id, whose value is hashed to sample log
+	// records that have no trace ID. All log records with the same value are either sampled or dropped together.
+	// Log records that have neither a trace ID nor the attribute are sampled randomly. Only used for logs.
+	FromAttribute string `mapstructure:"from_attribute"`
 }
 
 var _ config.Processor = (*Config)(nil)
@@ -39,6 +39,15 @@ func TestLoadConfig(t *testing.T) {
 				HashSeed:           22,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "logs"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 15.3,
+				HashSeed:           22,
+				FromAttribute:      "request.id",
+			},
+		},
 		{
 			id:       config.NewComponentIDWithName(typeStr, "empty"),
 			expected: createDefaultConfig(),
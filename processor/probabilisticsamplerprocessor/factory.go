@@ -25,8 +25,10 @@ import (
 const (
 	// The value of "type" trace-samplers in configuration.
 	typeStr = "probabilistic_sampler"
-	// The stability level of the processor.
+	// The stability level of the processor for traces.
 	stability = component.StabilityLevelBeta
+	// The stability level of the processor for logs.
+	logsStability = component.StabilityLevelAlpha
 )
 
 // NewFactory returns a new factory for the Probabilistic sampler processor.
@@ -34,7 +36,8 @@ func NewFactory() component.ProcessorFactory {
 	return component.NewProcessorFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesProcessor(createTracesProcessor, stability))
+		component.WithTracesProcessor(createTracesProcessor, stability),
+		component.WithLogsProcessor(createLogsProcessor, logsStability))
 }
 
 func createDefaultConfig() config.Processor {
@@ -52,3 +55,13 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	return newTracesProcessor(ctx, set, cfg.(*Config), nextConsumer)
 }
+
+// createLogsProcessor creates a log processor based on this config.
+func createLogsProcessor(
+	ctx context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	return newLogsProcessor(ctx, set, cfg.(*Config), nextConsumer)
+}
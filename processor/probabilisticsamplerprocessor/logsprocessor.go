@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"math/rand"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type logsamplerprocessor struct {
+	scaledSamplingRate uint32
+	hashSeed           uint32
+	fromAttribute      string
+	// randomBucket returns a random hash bucket for log records that have
+	// nothing to hash.
+	randomBucket func() uint32
+}
+
+// newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
+// configuration.
+func newLogsProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg *Config, nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	lsp := &logsamplerprocessor{
+		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
+		hashSeed:           cfg.HashSeed,
+		fromAttribute:      cfg.FromAttribute,
+		randomBucket: func() uint32 {
+			return uint32(rand.Int63n(numHashBuckets)) // #nosec
+		},
+	}
+
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		lsp.processLogs,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+func (lsp *logsamplerprocessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return lsp.bucket(lr) >= lsp.scaledSamplingRate
+			})
+			// Filter out empty ScopeLogs
+			return sl.LogRecords().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// bucket returns the hash bucket of a log record. Records that are part of a
+// trace are hashed on the trace ID, so they are sampled together with the spans
+// of the trace when the traces pipeline uses the same seed and percentage.
+// Other records are hashed on the configured attribute, if present, and are
+// otherwise assigned a random bucket.
+func (lsp *logsamplerprocessor) bucket(lr plog.LogRecord) uint32 {
+	if tid := lr.TraceID(); !tid.IsEmpty() {
+		return hash(tid[:], lsp.hashSeed) & bitMaskHashBuckets
+	}
+	if lsp.fromAttribute != "" {
+		if v, ok := lr.Attributes().Get(lsp.fromAttribute); ok {
+			return hash([]byte(v.AsString()), lsp.hashSeed) & bitMaskHashBuckets
+		}
+	}
+	return lsp.randomBucket()
+}
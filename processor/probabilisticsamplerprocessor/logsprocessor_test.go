@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+)
+
+func TestNewLogsProcessor(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 15.5,
+		FromAttribute:      "request.id",
+	}
+	lp, err := newLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.True(t, lp.Capabilities().MutatesData)
+}
+
+func Test_logsamplerprocessor_SamplingPercentageRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		samplingPercent float32
+		numLogs         int
+		acceptableDelta float64
+	}{
+		{
+			name:            "sampling_small",
+			samplingPercent: 5,
+			numLogs:         1e5,
+			acceptableDelta: 0.5,
+		},
+		{
+			name:            "sampling_medium",
+			samplingPercent: 50,
+			numLogs:         1e5,
+			acceptableDelta: 1,
+		},
+		{
+			name:            "sampling_all",
+			samplingPercent: 100,
+			numLogs:         1e4,
+			acceptableDelta: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			cfg := &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: tt.samplingPercent,
+				FromAttribute:      "request.id",
+			}
+			lp, err := newLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+			for i := 0; i < tt.numLogs; i++ {
+				lrs.AppendEmpty().Attributes().PutString("request.id", strconv.Itoa(i))
+			}
+			require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+			sampled := sink.LogRecordCount()
+			actual := float64(sampled) / float64(tt.numLogs) * 100.0
+			assert.InDelta(t, float64(tt.samplingPercent), actual, tt.acceptableDelta)
+		})
+	}
+}
+
+func Test_logsamplerprocessor_DropsEmptyBatch(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 0,
+	}
+	lp, err := newLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	assert.Empty(t, sink.AllLogs())
+}
+
+// Test_logsamplerprocessor_TraceIDConsistency checks that log records with a
+// trace ID get the same sampling decision as the spans of that trace.
+func Test_logsamplerprocessor_TraceIDConsistency(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 30,
+		HashSeed:           42,
+		FromAttribute:      "request.id",
+	}
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, tracesSink)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	lp, err := newLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, logsSink)
+	require.NoError(t, err)
+
+	const numTraces = 1000
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 1; i <= numTraces; i++ {
+		tid := idutils.UInt64ToTraceID(uint64(i), uint64(i*7))
+		span := spans.AppendEmpty()
+		span.SetTraceID(tid)
+		span.SetSpanID(idutils.UInt64ToSpanID(uint64(i)))
+		lr := lrs.AppendEmpty()
+		lr.SetTraceID(tid)
+		// The attribute must be ignored in favor of the trace ID.
+		lr.Attributes().PutString("request.id", "same")
+	}
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	sampledTraces := map[pcommon.TraceID]bool{}
+	for _, td := range tracesSink.AllTraces() {
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			sampledTraces[spans.At(i).TraceID()] = true
+		}
+	}
+	sampledLogs := map[pcommon.TraceID]bool{}
+	for _, ld := range logsSink.AllLogs() {
+		lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < lrs.Len(); i++ {
+			sampledLogs[lrs.At(i).TraceID()] = true
+		}
+	}
+	assert.NotEmpty(t, sampledLogs)
+	assert.Less(t, len(sampledLogs), numTraces)
+	assert.Equal(t, sampledTraces, sampledLogs)
+}
+
+func Test_logsamplerprocessor_FromAttribute(t *testing.T) {
+	lsp := &logsamplerprocessor{
+		scaledSamplingRate: uint32(50 * percentageScaleFactor),
+		hashSeed:           22,
+		fromAttribute:      "request.id",
+		randomBucket: func() uint32 {
+			return math.MaxUint32
+		},
+	}
+
+	lr := plog.NewLogRecord()
+	lr.Attributes().PutString("request.id", "abc")
+	want := hash([]byte("abc"), 22) & bitMaskHashBuckets
+	assert.Equal(t, want, lsp.bucket(lr))
+
+	// Records with the same attribute value are sampled together.
+	other := plog.NewLogRecord()
+	other.Attributes().PutString("request.id", "abc")
+	other.Attributes().PutString("ignored", "value")
+	assert.Equal(t, lsp.bucket(lr), lsp.bucket(other))
+
+	// Non-string values are hashed on their string representation.
+	intAttr := plog.NewLogRecord()
+	intAttr.Attributes().PutInt("request.id", 12)
+	assert.Equal(t, hash([]byte("12"), 22)&bitMaskHashBuckets, lsp.bucket(intAttr))
+
+	// Records without a trace ID or the attribute fall back to a random bucket.
+	missing := plog.NewLogRecord()
+	missing.Attributes().PutString("other", "abc")
+	assert.Equal(t, uint32(math.MaxUint32), lsp.bucket(missing))
+
+	lsp.fromAttribute = ""
+	assert.Equal(t, uint32(math.MaxUint32), lsp.bucket(lr))
+}
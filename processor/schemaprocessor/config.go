@@ -40,6 +40,11 @@ type Config struct {
 	// block processing of signals. (Optional field)
 	Prefetch []string `mapstructure:"prefetch"`
 
+	// Files is a list of local schema translation files that
+	// are used instead of downloading the schema URL they are
+	// published at. (Optional field)
+	Files []string `mapstructure:"files"`
+
 	// Targets define what schema families should be
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
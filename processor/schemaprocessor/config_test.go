@@ -46,6 +46,9 @@ func TestLoadConfig(t *testing.T) {
 		Prefetch: []string{
 			"https://opentelemetry.io/schemas/1.9.0",
 		},
+		Files: []string{
+			"/etc/otelcol/schemas/example-1.2.0.yaml",
+		},
 		Targets: []string{
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
@@ -0,0 +1,165 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// retryInterval is how long the manager waits before it looks up a schema
+// URL again that previously failed to load.
+const retryInterval = time.Minute
+
+// Manager is responsible for ensuring that schemas are kept up to date
+// with the most recent version that are requested.
+type Manager interface {
+	// RequestTranslation will provide either the defined Translation
+	// if it is a known target, or will return a nop Translation
+	// that does not change the data passed to it.
+	RequestTranslation(ctx context.Context, schemaURL string) Translation
+}
+
+type manager struct {
+	log      *zap.Logger
+	provider Provider
+	now      func() time.Time
+
+	// targets maps the schema family to the target schema URL.
+	targets map[string]string
+
+	// fetch serializes schema lookups so that concurrent requests for the
+	// same schema URL only load it once.
+	fetch sync.Mutex
+
+	rw           sync.RWMutex
+	translations map[string]*translator
+	failures     map[string]time.Time
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager that translates every schema family of targets
+// to the version of its target, loading schema files from provider as needed.
+func NewManager(targets []string, provider Provider, log *zap.Logger) (Manager, error) {
+	m := &manager{
+		log:          log,
+		provider:     provider,
+		now:          time.Now,
+		targets:      make(map[string]string, len(targets)),
+		translations: make(map[string]*translator, len(targets)),
+		failures:     make(map[string]time.Time),
+	}
+	for _, target := range targets {
+		family, _, err := GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = target
+	}
+	return m, nil
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) Translation {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return NewNopTranslation()
+	}
+	targetURL, ok := m.targets[family]
+	if !ok {
+		return NewNopTranslation()
+	}
+
+	if t, ok := m.cached(family, version); ok {
+		return t
+	}
+
+	m.fetch.Lock()
+	defer m.fetch.Unlock()
+
+	// Another request could have loaded the schema while waiting.
+	if t, ok := m.cached(family, version); ok {
+		return t
+	}
+
+	// The schema file published at the newer of the two versions contains
+	// all the changes needed to translate between them.
+	_, target, _ := GetFamilyAndVersion(targetURL)
+	lookupURL := targetURL
+	if version.GreaterThan(target) {
+		lookupURL = schemaURL
+	}
+
+	m.rw.RLock()
+	failedAt, failed := m.failures[lookupURL]
+	m.rw.RUnlock()
+	if failed && m.now().Sub(failedAt) < retryInterval {
+		return NewNopTranslation()
+	}
+
+	t, err := m.load(ctx, targetURL, lookupURL)
+	if err != nil {
+		m.log.Error("Unable to load schema translation",
+			zap.String("schema-url", lookupURL),
+			zap.Error(err),
+		)
+		m.rw.Lock()
+		m.failures[lookupURL] = m.now()
+		m.rw.Unlock()
+		return NewNopTranslation()
+	}
+
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	delete(m.failures, lookupURL)
+	if current, ok := m.translations[family]; !ok || t.latest().GreaterThan(current.latest()) {
+		m.translations[family] = t
+	}
+	if !t.SupportedVersion(version) {
+		// The schema file does not define the incoming version,
+		// so the signal is passed through untouched.
+		return NewNopTranslation()
+	}
+	return t
+}
+
+// cached returns the translation of the schema family if it is already
+// loaded and defines the requested version.
+func (m *manager) cached(family string, version *Version) (Translation, bool) {
+	m.rw.RLock()
+	defer m.rw.RUnlock()
+
+	t, ok := m.translations[family]
+	if !ok || !t.SupportedVersion(version) {
+		return nil, false
+	}
+	return t, true
+}
+
+func (m *manager) load(ctx context.Context, targetURL, schemaURL string) (*translator, error) {
+	content, err := m.provider.Lookup(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTranslation(targetURL, content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,131 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+// newSchemaServer serves the test schema with its schema family
+// replaced by the address of the server.
+func newSchemaServer(t *testing.T, requests *int64) *httptest.Server {
+	content := loadTestSchema(t)
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		if r.URL.Path != "/schemas/1.1.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write(bytes.ReplaceAll(content, []byte("https://opentelemetry.io"), []byte(s.URL)))
+		assert.NoError(t, err, "Must not error when writing schema content")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	var requests int64
+	s := newSchemaServer(t, &requests)
+
+	m, err := NewManager([]string{s.URL + "/schemas/1.0.0"}, NewHTTPProvider(s.Client()), zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	tn := m.RequestTranslation(context.Background(), s.URL+"/schemas/1.1.0")
+	assert.IsType(t, &translator{}, tn, "Must return the translation of the target family")
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must have fetched the schema file")
+
+	rs := ptrace.NewResourceSpans()
+	rs.SetSchemaUrl(s.URL + "/schemas/1.1.0")
+	rs.Resource().Attributes().PutString("kubernetes.pod.name", "pod")
+	require.NoError(t, tn.ApplyAllResourceChanges(rs))
+	assert.Equal(t, s.URL+"/schemas/1.0.0", rs.SchemaUrl(), "Must downgrade to the target")
+	assert.Equal(t, map[string]interface{}{"k8s.pod.name": "pod"}, rs.Resource().Attributes().AsRaw())
+
+	assert.Same(t, tn, m.RequestTranslation(context.Background(), s.URL+"/schemas/1.0.0"),
+		"Must reuse the cached translation for versions it defines")
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must not fetch the schema file again")
+
+	for _, schemaURL := range []string{
+		"",
+		"not a url",
+		"https://example.com/schemas/1.1.0",
+	} {
+		assert.Equal(t, NewNopTranslation(), m.RequestTranslation(context.Background(), schemaURL),
+			"Must return a nop translation for %q", schemaURL)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must not fetch schemas of other families")
+}
+
+func TestManagerRetriesFailures(t *testing.T) {
+	t.Parallel()
+
+	var requests int64
+	s := newSchemaServer(t, &requests)
+
+	m, err := NewManager([]string{s.URL + "/schemas/1.0.0"}, NewHTTPProvider(s.Client()), zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	now := time.Now()
+	m.(*manager).now = func() time.Time { return now }
+
+	unknown := s.URL + "/schemas/1.2.0"
+	assert.Equal(t, NewNopTranslation(), m.RequestTranslation(context.Background(), unknown))
+	assert.Equal(t, NewNopTranslation(), m.RequestTranslation(context.Background(), unknown))
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must not retry within the retry interval")
+
+	now = now.Add(retryInterval)
+	assert.Equal(t, NewNopTranslation(), m.RequestTranslation(context.Background(), unknown))
+	assert.EqualValues(t, 2, atomic.LoadInt64(&requests), "Must retry once the retry interval has passed")
+}
+
+func TestManagerInvalidTargets(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewManager([]string{"opentelemetry.io/schemas/1.0.0"}, nil, zaptest.NewLogger(t))
+	assert.ErrorIs(t, err, ErrInvalidFamily, "Must error with invalid targets")
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests int64
+	s := newSchemaServer(t, &requests)
+
+	m, err := NewManager([]string{s.URL + "/schemas/1.0.0"}, NewHTTPProvider(s.Client()), zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		tn := m.RequestTranslation(context.Background(), s.URL+"/schemas/1.1.0")
+		assert.True(t, tn.SupportedVersion(&Version{1, 1, 0}), "Must return the loaded translation")
+		return nil
+	})
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must only fetch the schema file once")
+}
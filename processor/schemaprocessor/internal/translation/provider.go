@@ -0,0 +1,138 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+)
+
+// ErrNotFound is returned by a Provider that does not know the schema URL.
+var ErrNotFound = errors.New("schema file not found")
+
+// Provider allows for collector extensions to be used to look up schema definitions.
+type Provider interface {
+	// Lookup returns the content of the schema file published at schemaURL.
+	Lookup(ctx context.Context, schemaURL string) (io.Reader, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a Provider that downloads schema files from the
+// schema URL using the provided client.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Lookup(ctx context.Context, schemaURL string) (io.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %q: %w", resp.StatusCode, schemaURL, ErrNotFound)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+type staticFile struct {
+	family  string
+	version *Version
+	content []byte
+}
+
+type staticProvider struct {
+	files []staticFile
+	next  Provider
+}
+
+var _ Provider = (*staticProvider)(nil)
+
+// NewStaticProvider reads the schema files from the local file system and
+// serves them by the schema URL each file declares. Since a schema file
+// contains every version of its family up to its own, a lookup of an older
+// version of the same family is served by the newest file. Any schema URL
+// that is not covered by the files is looked up with next, if it is not nil.
+func NewStaticProvider(next Provider, files ...string) (Provider, error) {
+	sp := &staticProvider{
+		files: make([]staticFile, 0, len(files)),
+		next:  next,
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		def, err := schema.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse schema file %q: %w", file, err)
+		}
+		family, version, err := GetFamilyAndVersion(def.SchemaURL)
+		if err != nil {
+			return nil, fmt.Errorf("schema file %q has an invalid schema url: %w", file, err)
+		}
+		sp.files = append(sp.files, staticFile{
+			family:  family,
+			version: version,
+			content: content,
+		})
+	}
+	return sp, nil
+}
+
+func (sp *staticProvider) Lookup(ctx context.Context, schemaURL string) (io.Reader, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	var match *staticFile
+	for i, f := range sp.files {
+		if f.family != family || f.version.LessThan(version) {
+			continue
+		}
+		if match == nil || f.version.GreaterThan(match.version) {
+			match = &sp.files[i]
+		}
+	}
+	if match != nil {
+		return bytes.NewReader(match.content), nil
+	}
+	if sp.next == nil {
+		return nil, fmt.Errorf("%q: %w", schemaURL, ErrNotFound)
+	}
+	return sp.next.Lookup(ctx, schemaURL)
+}
@@ -0,0 +1,84 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProvider(t *testing.T) {
+	t.Parallel()
+
+	var requests int64
+	s := newSchemaServer(t, &requests)
+
+	p, err := NewStaticProvider(NewHTTPProvider(s.Client()), filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must not error when loading schema files")
+
+	for _, schemaURL := range []string{
+		"https://opentelemetry.io/schemas/1.1.0",
+		"https://opentelemetry.io/schemas/1.0.0",
+	} {
+		r, err := p.Lookup(context.Background(), schemaURL)
+		require.NoError(t, err, "Must serve %q from the local file", schemaURL)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, loadTestSchema(t), content, "Must serve the content of the local file")
+	}
+	assert.Zero(t, atomic.LoadInt64(&requests), "Must not fall back for known schema urls")
+
+	r, err := p.Lookup(context.Background(), s.URL+"/schemas/1.1.0")
+	require.NoError(t, err, "Must fall back to the next provider")
+	assert.NotNil(t, r)
+	assert.EqualValues(t, 1, atomic.LoadInt64(&requests), "Must have used the next provider")
+
+	_, err = p.Lookup(context.Background(), "https://opentelemetry.io/schemas/1.2.0")
+	assert.Error(t, err, "Must error when no file covers the version")
+}
+
+func TestStaticProviderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStaticProvider(nil, filepath.Join("testdata", "does-not-exist.yml"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "Must error on missing files")
+
+	invalid := filepath.Join(t.TempDir(), "invalid.yml")
+	require.NoError(t, os.WriteFile(invalid, []byte("file_format: 1.0.0\nschema_url: example.com/1.0.0\n"), 0600))
+	_, err = NewStaticProvider(nil, invalid)
+	assert.Error(t, err, "Must error on invalid schema files")
+
+	p, err := NewStaticProvider(nil)
+	require.NoError(t, err)
+	_, err = p.Lookup(context.Background(), "https://opentelemetry.io/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrNotFound, "Must error when the schema is unknown")
+}
+
+func TestHTTPProviderNotFound(t *testing.T) {
+	t.Parallel()
+
+	var requests int64
+	s := newSchemaServer(t, &requests)
+
+	_, err := NewHTTPProvider(s.Client()).Lookup(context.Background(), s.URL+"/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrNotFound, "Must error when the schema is not served")
+}
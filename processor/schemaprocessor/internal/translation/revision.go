@@ -0,0 +1,345 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	ast10 "go.opentelemetry.io/otel/schema/v1.0/ast"
+	ast11 "go.opentelemetry.io/otel/schema/v1.1/ast"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// renames maps the name used before a change to the name used after it.
+type renames map[string]string
+
+// invert returns the renames that undo the change.
+func (r renames) invert() renames {
+	inverted := make(renames, len(r))
+	for from, to := range r {
+		inverted[to] = from
+	}
+	return inverted
+}
+
+// applyToAttributes renames all matching keys of attrs at once, so that
+// chained renames within one change (a -> b, b -> c) are not applied twice.
+func (r renames) applyToAttributes(attrs pcommon.Map) {
+	if len(r) == 0 {
+		return
+	}
+	moved := make(map[string]pcommon.Value)
+	attrs.RemoveIf(func(k string, v pcommon.Value) bool {
+		to, ok := r[k]
+		if !ok {
+			return false
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		moved[to] = val
+		return true
+	})
+	for k, v := range moved {
+		v.CopyTo(attrs.PutEmpty(k))
+	}
+}
+
+func (r renames) applyToName(s alias.Signal) {
+	if to, ok := r[s.Name()]; ok {
+		s.SetName(to)
+	}
+}
+
+// names is a set of names a change is restricted to, an empty set matches
+// every name.
+type names map[string]struct{}
+
+func newNames[T ~string](in []T) names {
+	set := make(names, len(in))
+	for _, n := range in {
+		set[string(n)] = struct{}{}
+	}
+	return set
+}
+
+func (n names) matches(name string) bool {
+	if len(n) == 0 {
+		return true
+	}
+	_, ok := n[name]
+	return ok
+}
+
+type spanChange struct {
+	attributes renames
+	spans      names
+}
+
+type spanEventChange struct {
+	events     renames
+	attributes renames
+	spans      names
+	eventNames names
+}
+
+type metricChange struct {
+	metrics    renames
+	attributes renames
+	applyTo    names
+}
+
+// changes holds all the changes of one version of a schema file, in the
+// order they have to be applied in a single direction.
+type changes struct {
+	upgrade    bool
+	all        []renames
+	resources  []renames
+	spans      []spanChange
+	spanEvents []spanEventChange
+	metrics    []metricChange
+	logs       []renames
+}
+
+// revision is a single version of a schema family along with the changes
+// needed to upgrade to it from the previous version, and to downgrade from
+// it to the previous version.
+type revision struct {
+	ver       *Version
+	upgrade   changes
+	downgrade changes
+}
+
+func newRevision(ver *Version, def ast11.VersionDef) *revision {
+	r := &revision{ver: ver}
+	r.upgrade.upgrade = true
+
+	r.upgrade.all = attributeChanges(def.All)
+	r.upgrade.resources = attributeChanges(def.Resources)
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes != nil {
+			r.upgrade.spans = append(r.upgrade.spans, spanChange{
+				attributes: renames(c.RenameAttributes.AttributeMap),
+				spans:      newNames(c.RenameAttributes.ApplyToSpans),
+			})
+		}
+	}
+	for _, c := range def.SpanEvents.Changes {
+		var change spanEventChange
+		if c.RenameEvents != nil {
+			change.events = c.RenameEvents.EventNameMap
+		}
+		if c.RenameAttributes != nil {
+			change.attributes = renames(c.RenameAttributes.AttributeMap)
+			change.spans = newNames(c.RenameAttributes.ApplyToSpans)
+			change.eventNames = newNames(c.RenameAttributes.ApplyToEvents)
+		}
+		r.upgrade.spanEvents = append(r.upgrade.spanEvents, change)
+	}
+	for _, c := range def.Metrics.Changes {
+		// Splitting metrics, added in file format 1.1.0, is not supported
+		// and is ignored.
+		change := metricChange{metrics: make(renames, len(c.RenameMetrics))}
+		for from, to := range c.RenameMetrics {
+			change.metrics[string(from)] = string(to)
+		}
+		if c.RenameAttributes != nil {
+			change.attributes = renames(c.RenameAttributes.AttributeMap)
+			change.applyTo = newNames(c.RenameAttributes.ApplyToMetrics)
+		}
+		r.upgrade.metrics = append(r.upgrade.metrics, change)
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			r.upgrade.logs = append(r.upgrade.logs, renames(c.RenameAttributes.AttributeMap))
+		}
+	}
+
+	r.downgrade = r.upgrade.invert()
+	return r
+}
+
+func attributeChanges(attrs ast10.Attributes) []renames {
+	var out []renames
+	for _, c := range attrs.Changes {
+		if c.RenameAttributes != nil {
+			out = append(out, renames(*c.RenameAttributes))
+		}
+	}
+	return out
+}
+
+// invert returns the changes that undo c. The renames are inverted and
+// applied in reverse order. The name restrictions of a change stay the same,
+// since span names are not changed by a schema and event and metric names
+// are restored before the attribute changes of the same change are undone.
+func (c changes) invert() changes {
+	inv := changes{upgrade: !c.upgrade}
+	for i := len(c.all) - 1; i >= 0; i-- {
+		inv.all = append(inv.all, c.all[i].invert())
+	}
+	for i := len(c.resources) - 1; i >= 0; i-- {
+		inv.resources = append(inv.resources, c.resources[i].invert())
+	}
+	for i := len(c.spans) - 1; i >= 0; i-- {
+		inv.spans = append(inv.spans, spanChange{
+			attributes: c.spans[i].attributes.invert(),
+			spans:      c.spans[i].spans,
+		})
+	}
+	for i := len(c.spanEvents) - 1; i >= 0; i-- {
+		change := c.spanEvents[i]
+		inv.spanEvents = append(inv.spanEvents, spanEventChange{
+			events:     change.events.invert(),
+			attributes: change.attributes.invert(),
+			spans:      change.spans,
+			eventNames: change.eventNames,
+		})
+	}
+	for i := len(c.metrics) - 1; i >= 0; i-- {
+		change := c.metrics[i]
+		inv.metrics = append(inv.metrics, metricChange{
+			metrics:    change.metrics.invert(),
+			attributes: change.attributes.invert(),
+			applyTo:    change.applyTo,
+		})
+	}
+	for i := len(c.logs) - 1; i >= 0; i-- {
+		inv.logs = append(inv.logs, c.logs[i].invert())
+	}
+	return inv
+}
+
+// withAll applies the changes to all attributes around fn, which applies
+// the section specific changes. Changes to all attributes come first when
+// upgrading and last when downgrading.
+func (c changes) withAll(attrs pcommon.Map, fn func()) {
+	if c.upgrade {
+		c.applyAll(attrs)
+	}
+	fn()
+	if !c.upgrade {
+		c.applyAll(attrs)
+	}
+}
+
+func (c changes) applyAll(attrs pcommon.Map) {
+	for _, r := range c.all {
+		r.applyToAttributes(attrs)
+	}
+}
+
+func (c changes) applyResource(resource pcommon.Resource) {
+	attrs := resource.Attributes()
+	c.withAll(attrs, func() {
+		for _, r := range c.resources {
+			r.applyToAttributes(attrs)
+		}
+	})
+}
+
+func (c changes) applySpan(span ptrace.Span) {
+	attrs := span.Attributes()
+	c.withAll(attrs, func() {
+		for _, change := range c.spans {
+			if change.spans.matches(span.Name()) {
+				change.attributes.applyToAttributes(attrs)
+			}
+		}
+	})
+
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		c.applySpanEvent(span.Name(), events.At(i))
+	}
+}
+
+func (c changes) applySpanEvent(spanName string, event ptrace.SpanEvent) {
+	attrs := event.Attributes()
+	c.withAll(attrs, func() {
+		for _, change := range c.spanEvents {
+			if c.upgrade {
+				change.events.applyToName(event)
+			}
+			if change.spans.matches(spanName) && change.eventNames.matches(event.Name()) {
+				change.attributes.applyToAttributes(attrs)
+			}
+			if !c.upgrade {
+				change.events.applyToName(event)
+			}
+		}
+	})
+}
+
+func (c changes) applyMetric(metric pmetric.Metric) {
+	if c.upgrade {
+		forEachDataPointAttributes(metric, c.applyAll)
+	}
+	for _, change := range c.metrics {
+		if c.upgrade {
+			change.metrics.applyToName(metric)
+		}
+		if len(change.attributes) > 0 && change.applyTo.matches(metric.Name()) {
+			forEachDataPointAttributes(metric, change.attributes.applyToAttributes)
+		}
+		if !c.upgrade {
+			change.metrics.applyToName(metric)
+		}
+	}
+	if !c.upgrade {
+		forEachDataPointAttributes(metric, c.applyAll)
+	}
+}
+
+func (c changes) applyLogRecord(lr plog.LogRecord) {
+	attrs := lr.Attributes()
+	c.withAll(attrs, func() {
+		for _, r := range c.logs {
+			r.applyToAttributes(attrs)
+		}
+	})
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+	"go.opentelemetry.io/otel/schema/v1.1/ast"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// Translation defines the complete abstraction of a schema translation file
+// that is defined as part of the https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.1.0/
+// and is able to translate telemetry of its schema family to a fixed target version.
+//
+// Any signal published with a schema URL of a different schema family, or
+// with a version unknown to the schema file, is left untouched.
+type Translation interface {
+	// SupportedVersion checks to see if the provided version is defined as part
+	// of this translation since it is useful to know if the translation is missing
+	// updates from the schema URL.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges translates the resource attributes from the
+	// schema URL of the resource to the target version and updates the
+	// schema URL accordingly.
+	ApplyAllResourceChanges(in alias.Resource) error
+
+	// ApplyScopeSpanChanges translates all spans and span events of the scope
+	// from inSchemaURL, which is the scope schema URL if set or the resource
+	// schema URL otherwise, to the target version.
+	ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error
+
+	// ApplyScopeLogChanges translates all log records of the scope from
+	// inSchemaURL to the target version.
+	ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error
+
+	// ApplyScopeMetricChanges translates all metrics of the scope from
+	// inSchemaURL to the target version.
+	ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error
+}
+
+type translator struct {
+	family    string
+	targetURL string
+	target    *Version
+	// revisions are sorted by version in ascending order.
+	revisions []*revision
+}
+
+var _ Translation = (*translator)(nil)
+
+// NewTranslation parses the schema file read from content and returns a
+// Translation that converts signals of the same schema family to the
+// version of targetSchemaURL.
+func NewTranslation(targetSchemaURL string, content io.Reader) (Translation, error) {
+	return parseTranslation(targetSchemaURL, content)
+}
+
+func parseTranslation(targetSchemaURL string, content io.Reader) (*translator, error) {
+	family, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	def, err := schema.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse schema file: %w", err)
+	}
+	if defFamily, _, err := GetFamilyAndVersion(def.SchemaURL); err != nil || defFamily != family {
+		return nil, fmt.Errorf("schema file %q does not belong to the schema family %q: %w", def.SchemaURL, family, ErrInvalidFamily)
+	}
+	return newTranslator(family, targetSchemaURL, target, def)
+}
+
+func newTranslator(family, targetURL string, target *Version, def *ast.Schema) (*translator, error) {
+	t := &translator{
+		family:    family,
+		targetURL: targetURL,
+		target:    target,
+		revisions: make([]*revision, 0, len(def.Versions)),
+	}
+	for v, changes := range def.Versions {
+		ver, err := NewVersion(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("schema file defines invalid version %q: %w", v, err)
+		}
+		t.revisions = append(t.revisions, newRevision(ver, changes))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].ver.LessThan(t.revisions[j].ver)
+	})
+	if !t.SupportedVersion(target) {
+		return nil, fmt.Errorf("target version %s is not defined in the schema file: %w", target, ErrInvalidVersion)
+	}
+	return t, nil
+}
+
+// latest returns the most recent version defined by the schema file.
+func (t *translator) latest() *Version {
+	return t.revisions[len(t.revisions)-1].ver
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	i := sort.Search(len(t.revisions), func(i int) bool {
+		return !t.revisions[i].ver.LessThan(v)
+	})
+	return i < len(t.revisions) && t.revisions[i].ver.Equal(v)
+}
+
+// changesFor returns the changes required to translate from schemaURL to
+// the target version in the order they need to be applied. It returns
+// false if the signal can not be translated and should be left untouched.
+func (t *translator) changesFor(schemaURL string) ([]changes, bool) {
+	family, ver, err := GetFamilyAndVersion(schemaURL)
+	if err != nil || family != t.family || !t.SupportedVersion(ver) {
+		return nil, false
+	}
+	var out []changes
+	switch ver.Compare(t.target) {
+	case -1:
+		for _, r := range t.revisions {
+			if r.ver.GreaterThan(ver) && !r.ver.GreaterThan(t.target) {
+				out = append(out, r.upgrade)
+			}
+		}
+	case 1:
+		for i := len(t.revisions) - 1; i >= 0; i-- {
+			r := t.revisions[i]
+			if r.ver.GreaterThan(t.target) && !r.ver.GreaterThan(ver) {
+				out = append(out, r.downgrade)
+			}
+		}
+	}
+	return out, true
+}
+
+func (t *translator) ApplyAllResourceChanges(in alias.Resource) error {
+	cs, ok := t.changesFor(in.SchemaUrl())
+	if !ok {
+		return nil
+	}
+	for _, c := range cs {
+		c.applyResource(in.Resource())
+	}
+	in.SetSchemaUrl(t.targetURL)
+	return nil
+}
+
+func (t *translator) ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error {
+	cs, ok := t.changesFor(inSchemaURL)
+	if !ok {
+		return nil
+	}
+	spans := in.Spans()
+	for _, c := range cs {
+		for i := 0; i < spans.Len(); i++ {
+			c.applySpan(spans.At(i))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetURL)
+	}
+	return nil
+}
+
+func (t *translator) ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error {
+	cs, ok := t.changesFor(inSchemaURL)
+	if !ok {
+		return nil
+	}
+	logs := in.LogRecords()
+	for _, c := range cs {
+		for i := 0; i < logs.Len(); i++ {
+			c.applyLogRecord(logs.At(i))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetURL)
+	}
+	return nil
+}
+
+func (t *translator) ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error {
+	cs, ok := t.changesFor(inSchemaURL)
+	if !ok {
+		return nil
+	}
+	metrics := in.Metrics()
+	for _, c := range cs {
+		for i := 0; i < metrics.Len(); i++ {
+			c.applyMetric(metrics.At(i))
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetURL)
+	}
+	return nil
+}
+
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+// NewNopTranslation returns a Translation that leaves all signals untouched.
+func NewNopTranslation() Translation {
+	return nopTranslation{}
+}
+
+func (nopTranslation) SupportedVersion(_ *Version) bool {
+	return false
+}
+
+func (nopTranslation) ApplyAllResourceChanges(_ alias.Resource) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ string) error {
+	return nil
+}
@@ -0,0 +1,282 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	schemaV100 = "https://opentelemetry.io/schemas/1.0.0"
+	schemaV110 = "https://opentelemetry.io/schemas/1.1.0"
+)
+
+func loadTestSchema(t *testing.T) []byte {
+	content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to read the test schema")
+	return content
+}
+
+func newTestTranslation(t *testing.T, target string) Translation {
+	tn, err := NewTranslation(target, bytes.NewReader(loadTestSchema(t)))
+	require.NoError(t, err, "Must not error when creating translation")
+	return tn
+}
+
+func TestNewTranslation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+		target   string
+		content  string
+		err      error
+	}{
+		{
+			scenario: "valid target",
+			target:   schemaV100,
+			content:  string(loadTestSchema(t)),
+		},
+		{
+			scenario: "target version not defined",
+			target:   "https://opentelemetry.io/schemas/1.2.0",
+			content:  string(loadTestSchema(t)),
+			err:      ErrInvalidVersion,
+		},
+		{
+			scenario: "schema file of another family",
+			target:   "https://example.com/schemas/1.0.0",
+			content:  string(loadTestSchema(t)),
+			err:      ErrInvalidFamily,
+		},
+		{
+			scenario: "invalid target",
+			target:   "opentelemetry.io/schemas/1.0.0",
+			content:  string(loadTestSchema(t)),
+			err:      ErrInvalidFamily,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tn, err := NewTranslation(tc.target, strings.NewReader(tc.content))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err, "Must match the expected error")
+				assert.Nil(t, tn, "Must not return a translation on error")
+				return
+			}
+			assert.NoError(t, err, "Must not error when creating translation")
+			assert.NotNil(t, tn, "Must return a translation")
+		})
+	}
+
+	_, err := NewTranslation(schemaV100, strings.NewReader("not: [a, schema"))
+	assert.Error(t, err, "Must error when the schema file can not be parsed")
+}
+
+func TestTranslationSupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslation(t, schemaV110)
+	assert.True(t, tn.SupportedVersion(&Version{1, 0, 0}), "Must support the first version")
+	assert.True(t, tn.SupportedVersion(&Version{1, 1, 0}), "Must support the latest version")
+	assert.False(t, tn.SupportedVersion(&Version{1, 2, 0}), "Must not support versions outside the file")
+	assert.False(t, NewNopTranslation().SupportedVersion(&Version{1, 0, 0}), "Nop translation supports no version")
+}
+
+func newResourceSpans(schemaURL string) ptrace.ResourceSpans {
+	rs := ptrace.NewResourceSpans()
+	rs.SetSchemaUrl(schemaURL)
+	rs.Resource().Attributes().PutString("telemetry.auto.version", "1.2.3")
+	rs.Resource().Attributes().PutString("k8s.pod.name", "pod")
+	return rs
+}
+
+func TestTranslationResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	rs := newResourceSpans(schemaV100)
+	require.NoError(t, newTestTranslation(t, schemaV110).ApplyAllResourceChanges(rs))
+	assert.Equal(t, schemaV110, rs.SchemaUrl(), "Must update the schema url")
+	assert.Equal(t, map[string]interface{}{
+		"telemetry.auto_instr.version": "1.2.3",
+		"kubernetes.pod.name":          "pod",
+	}, rs.Resource().Attributes().AsRaw())
+
+	require.NoError(t, newTestTranslation(t, schemaV100).ApplyAllResourceChanges(rs))
+	assert.Equal(t, schemaV100, rs.SchemaUrl(), "Must update the schema url")
+	assert.Equal(t, newResourceSpans(schemaV100).Resource().Attributes().AsRaw(), rs.Resource().Attributes().AsRaw(),
+		"Must restore the original attributes when downgrading")
+}
+
+func TestTranslationIgnoresUnknownSchemas(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslation(t, schemaV110)
+	for _, schemaURL := range []string{
+		"",
+		"https://example.com/schemas/1.0.0",
+		"https://opentelemetry.io/schemas/0.9.0",
+	} {
+		rs := newResourceSpans(schemaURL)
+		require.NoError(t, tn.ApplyAllResourceChanges(rs))
+		assert.Equal(t, newResourceSpans(schemaURL), rs, "Must not modify %q", schemaURL)
+	}
+}
+
+func newScopeSpans() ptrace.ScopeSpans {
+	ss := ptrace.NewScopeSpans()
+	get := ss.Spans().AppendEmpty()
+	get.SetName("HTTP GET")
+	get.Attributes().PutString("peer.service", "backend")
+	get.Attributes().PutString("k8s.node.name", "node")
+	ev := get.Events().AppendEmpty()
+	ev.SetName("stacktrace")
+	ev.Attributes().PutString("peer.service", "backend")
+	post := ss.Spans().AppendEmpty()
+	post.SetName("HTTP POST")
+	post.Attributes().PutString("peer.service", "backend")
+	return ss
+}
+
+func TestTranslationSpanChanges(t *testing.T) {
+	t.Parallel()
+
+	ss := newScopeSpans()
+	require.NoError(t, newTestTranslation(t, schemaV110).ApplyScopeSpanChanges(ss, schemaV100))
+	assert.Empty(t, ss.SchemaUrl(), "Must not set the scope schema url if it was not set")
+
+	get := ss.Spans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"peer.service.name":    "backend",
+		"kubernetes.node.name": "node",
+	}, get.Attributes().AsRaw())
+	ev := get.Events().At(0)
+	assert.Equal(t, "stack_trace", ev.Name(), "Must rename the span event")
+	assert.Equal(t, map[string]interface{}{
+		"peer.service": "backend",
+	}, ev.Attributes().AsRaw(), "Must only rename attributes of the listed events")
+	assert.Equal(t, map[string]interface{}{
+		"peer.service": "backend",
+	}, ss.Spans().At(1).Attributes().AsRaw(), "Must only rename attributes of the listed spans")
+
+	ss.SetSchemaUrl(schemaV110)
+	require.NoError(t, newTestTranslation(t, schemaV100).ApplyScopeSpanChanges(ss, schemaV110))
+	assert.Equal(t, schemaV100, ss.SchemaUrl(), "Must update the scope schema url")
+
+	expect := newScopeSpans()
+	for i := 0; i < expect.Spans().Len(); i++ {
+		want, got := expect.Spans().At(i), ss.Spans().At(i)
+		assert.Equal(t, want.Name(), got.Name())
+		assert.Equal(t, want.Attributes().AsRaw(), got.Attributes().AsRaw(), "Must restore the original attributes when downgrading")
+		for j := 0; j < want.Events().Len(); j++ {
+			assert.Equal(t, want.Events().At(j).Name(), got.Events().At(j).Name(), "Must restore the original event name when downgrading")
+			assert.Equal(t, want.Events().At(j).Attributes().AsRaw(), got.Events().At(j).Attributes().AsRaw())
+		}
+	}
+}
+
+func TestTranslationMetricChanges(t *testing.T) {
+	t.Parallel()
+
+	newScopeMetrics := func() pmetric.ScopeMetrics {
+		sm := pmetric.NewScopeMetrics()
+		cpu := sm.Metrics().AppendEmpty()
+		cpu.SetName("container.cpu.usage.total")
+		cpu.SetEmptySum()
+		dp := cpu.Sum().DataPoints().AppendEmpty()
+		dp.SetIntVal(1)
+		dp.Attributes().PutString("k8s.container.name", "app")
+		dp.Attributes().PutString("status", "idle")
+		mem := sm.Metrics().AppendEmpty()
+		mem.SetName("system.memory.usage")
+		mem.SetEmptyGauge()
+		dp = mem.Gauge().DataPoints().AppendEmpty()
+		dp.SetIntVal(2)
+		dp.Attributes().PutString("status", "used")
+		return sm
+	}
+
+	sm := newScopeMetrics()
+	require.NoError(t, newTestTranslation(t, schemaV110).ApplyScopeMetricChanges(sm, schemaV100))
+
+	cpu := sm.Metrics().At(0)
+	assert.Equal(t, "cpu.usage.total", cpu.Name(), "Must rename the metric")
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.container.name": "app",
+		"status":                    "idle",
+	}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	mem := sm.Metrics().At(1)
+	assert.Equal(t, "system.memory.usage", mem.Name())
+	assert.Equal(t, map[string]interface{}{
+		"state": "used",
+	}, mem.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	require.NoError(t, newTestTranslation(t, schemaV100).ApplyScopeMetricChanges(sm, schemaV110))
+	assert.Equal(t, "container.cpu.usage.total", cpu.Name(), "Must restore the metric name when downgrading")
+	assert.Equal(t, newScopeMetrics().Metrics().At(0).Sum().DataPoints().At(0).Attributes().AsRaw(),
+		cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, newScopeMetrics().Metrics().At(1).Gauge().DataPoints().At(0).Attributes().AsRaw(),
+		mem.Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslationLogChanges(t *testing.T) {
+	t.Parallel()
+
+	newScopeLogs := func() plog.ScopeLogs {
+		sl := plog.NewScopeLogs()
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStringVal("hello")
+		lr.Attributes().PutString("process.executable_name", "otelcol")
+		lr.Attributes().PutString("k8s.job.name", "job")
+		return sl
+	}
+
+	sl := newScopeLogs()
+	require.NoError(t, newTestTranslation(t, schemaV110).ApplyScopeLogChanges(sl, schemaV100))
+	assert.Equal(t, map[string]interface{}{
+		"process.executable.name": "otelcol",
+		"kubernetes.job.name":     "job",
+	}, sl.LogRecords().At(0).Attributes().AsRaw())
+
+	require.NoError(t, newTestTranslation(t, schemaV100).ApplyScopeLogChanges(sl, schemaV110))
+	assert.Equal(t, newScopeLogs().LogRecords().At(0).Attributes().AsRaw(), sl.LogRecords().At(0).Attributes().AsRaw(),
+		"Must restore the original logs when downgrading")
+}
+
+func TestRenamesChained(t *testing.T) {
+	t.Parallel()
+
+	attrs := pcommon.NewMap()
+	attrs.PutString("a", "first")
+	attrs.PutString("b", "second")
+
+	renames{"a": "b", "b": "c"}.applyToAttributes(attrs)
+	assert.Equal(t, map[string]interface{}{
+		"b": "first",
+		"c": "second",
+	}, attrs.AsRaw(), "Must apply all renames of a change at once")
+}
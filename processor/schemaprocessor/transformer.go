@@ -20,15 +20,24 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets   []string
+	prefetch  []string
+	files     []string
+	client    confighttp.HTTPClientSettings
+	log       *zap.Logger
+	telemetry component.TelemetrySettings
+
+	manager translation.Manager
 }
 
 func newTransformer(
@@ -41,28 +50,129 @@ func newTransformer(
 		return nil, errors.New("invalid configuration provided")
 	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:       set.Logger,
+		telemetry: set.TelemetrySettings,
+		targets:   cfg.Targets,
+		prefetch:  cfg.Prefetch,
+		files:     cfg.Files,
+		client:    cfg.HTTPClientSettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	if t.manager == nil {
+		return ld, nil
+	}
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		err := t.manager.
+			RequestTranslation(ctx, resourceSchemaURL).
+			ApplyAllResourceChanges(rLog)
+		if err != nil {
+			return plog.NewLogs(), err
+		}
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			log := rLog.ScopeLogs().At(sl)
+			logSchemaURL := log.SchemaUrl()
+			if logSchemaURL == "" {
+				logSchemaURL = resourceSchemaURL
+			}
+			err := t.manager.
+				RequestTranslation(ctx, logSchemaURL).
+				ApplyScopeLogChanges(log, logSchemaURL)
+			if err != nil {
+				return plog.NewLogs(), err
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if t.manager == nil {
+		return md, nil
+	}
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		err := t.manager.
+			RequestTranslation(ctx, resourceSchemaURL).
+			ApplyAllResourceChanges(rMetric)
+		if err != nil {
+			return pmetric.NewMetrics(), err
+		}
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			metric := rMetric.ScopeMetrics().At(sm)
+			metricSchemaURL := metric.SchemaUrl()
+			if metricSchemaURL == "" {
+				metricSchemaURL = resourceSchemaURL
+			}
+			err := t.manager.
+				RequestTranslation(ctx, metricSchemaURL).
+				ApplyScopeMetricChanges(metric, metricSchemaURL)
+			if err != nil {
+				return pmetric.NewMetrics(), err
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	if t.manager == nil {
+		return td, nil
+	}
+	for rt := 0; rt < td.ResourceSpans().Len(); rt++ {
+		rTrace := td.ResourceSpans().At(rt)
+		resourceSchemaURL := rTrace.SchemaUrl()
+		err := t.manager.
+			RequestTranslation(ctx, resourceSchemaURL).
+			ApplyAllResourceChanges(rTrace)
+		if err != nil {
+			return ptrace.NewTraces(), err
+		}
+		for ss := 0; ss < rTrace.ScopeSpans().Len(); ss++ {
+			span := rTrace.ScopeSpans().At(ss)
+			spanSchemaURL := span.SchemaUrl()
+			if spanSchemaURL == "" {
+				spanSchemaURL = resourceSchemaURL
+			}
+			err := t.manager.
+				RequestTranslation(ctx, spanSchemaURL).
+				ApplyScopeSpanChanges(span, spanSchemaURL)
+			if err != nil {
+				return ptrace.NewTraces(), err
+			}
+		}
+	}
 	return td, nil
 }
 
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.client.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	provider := translation.NewHTTPProvider(client)
+	if len(t.files) > 0 {
+		provider, err = translation.NewStaticProvider(provider, t.files...)
+		if err != nil {
+			return err
+		}
+	}
+	t.manager, err = translation.NewManager(t.targets, provider, t.log)
+	if err != nil {
+		return err
+	}
+	schemaURLs := make([]string, 0, len(t.prefetch)+len(t.targets))
+	schemaURLs = append(schemaURLs, t.prefetch...)
+	schemaURLs = append(schemaURLs, t.targets...)
+	for _, schemaURL := range schemaURLs {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		t.manager.RequestTranslation(ctx, schemaURL)
 	}
 	return nil
 }
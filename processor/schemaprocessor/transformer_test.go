@@ -18,11 +18,13 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -108,3 +110,81 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.1.0"}
+	cfg.Files = []string{filepath.Join("testdata", "schema.yml")}
+
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		rm.Resource().Attributes().PutString("k8s.pod.name", "pod")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("container.cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleVal(1)
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+		rm = out.ResourceMetrics().At(0)
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", rm.SchemaUrl(), "Must update the schema url")
+		assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod"}, rm.Resource().Attributes().AsRaw())
+		assert.Equal(t, "cpu.usage.total", rm.ScopeMetrics().At(0).Metrics().At(0).Name())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		s := ss.Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().PutString("peer.service", "backend")
+		s.Events().AppendEmpty().SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+		ss = out.ResourceSpans().At(0).ScopeSpans().At(0)
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", ss.SchemaUrl(), "Must update the scope schema url")
+		s = ss.Spans().At(0)
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, s.Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", s.Events().At(0).Name())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Attributes().PutString("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+		lr = out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, map[string]interface{}{"process.executable.name": "otelcol"}, lr.Attributes().AsRaw())
+	})
+
+	t.Run("unknown family", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutString("process.executable_name", "otelcol")
+		expect := in.Clone()
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+		assert.Equal(t, expect, out, "Must not change signals of other schema families")
+	})
+}
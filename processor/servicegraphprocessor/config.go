@@ -40,6 +40,15 @@ type Config struct {
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []string `mapstructure:"dimensions"`
 
+	// VirtualNodePeerAttributes is the list of client span attributes, in order of precedence, used to name
+	// the server of requests to uninstrumented services, such as databases or third-party APIs.
+	// When set, requests whose client or server span expires unpaired are reported with a virtual node
+	// in place of the missing service:
+	// - the server is named after the first of these attributes found on the client span, or "unknown".
+	// - the client of root server spans is named "user".
+	// Those edges carry the virtual_node dimension, holding the side which is virtual.
+	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
+
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
 }
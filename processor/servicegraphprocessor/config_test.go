@@ -41,10 +41,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 	assert.Equal(t,
 		&Config{
-			ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
-			MetricsExporter:         "metrics",
-			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
-			Dimensions:              []string{"dimension-1", "dimension-2"},
+			ProcessorSettings:         config.NewProcessorSettings(config.NewComponentID(typeStr)),
+			MetricsExporter:           "metrics",
+			LatencyHistogramBuckets:   []time.Duration{1, 2, 3, 4, 5},
+			Dimensions:                []string{"dimension-1", "dimension-2"},
+			VirtualNodePeerAttributes: []string{"peer.service", "db.system"},
 			Store: StoreConfig{
 				TTL:      time.Second,
 				MaxItems: 10,
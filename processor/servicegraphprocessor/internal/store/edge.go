@@ -28,6 +28,16 @@ const (
 	Database        ConnectionType = "database"
 )
 
+// VirtualNode is the side of an Edge which wasn't instrumented, and was named
+// after the attributes of the other side.
+type VirtualNode string
+
+const (
+	NotVirtual    VirtualNode = ""
+	ClientVirtual VirtualNode = "client"
+	ServerVirtual VirtualNode = "server"
+)
+
 // Edge is an Edge between two nodes in the graph
 type Edge struct {
 	key string
@@ -44,6 +54,17 @@ type Edge struct {
 	// Additional dimension to add to the metrics
 	Dimensions map[string]string
 
+	// Peer holds the attributes of the client span describing its peer, used to
+	// name the server if it never reports a span.
+	Peer map[string]string
+
+	// ServerIsRoot is true if the server span has no parent, so its client
+	// can't be instrumented.
+	ServerIsRoot bool
+
+	// VirtualNode is set if one of the nodes wasn't instrumented.
+	VirtualNode VirtualNode
+
 	// expiration is the time at which the Edge expires, expressed as Unix time
 	expiration time.Time
 }
@@ -52,6 +73,7 @@ func newEdge(key string, ttl time.Duration) *Edge {
 	return &Edge{
 		key:        key,
 		Dimensions: make(map[string]string),
+		Peer:       make(map[string]string),
 		expiration: time.Now().Add(ttl),
 	}
 }
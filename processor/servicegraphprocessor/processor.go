@@ -38,6 +38,11 @@ import (
 
 const (
 	metricKeySeparator = string(byte(0))
+
+	// virtualClientName is the name of the client of root server spans.
+	virtualClientName = "user"
+	// unknownServerName is the name of the server when no peer attribute was found.
+	unknownServerName = "unknown"
 )
 
 var (
@@ -205,6 +210,7 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 						e.ClientLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(e.Dimensions, rAttributes, span.Attributes())
+						p.upsertPeerAttributes(e.Peer, span.Attributes())
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
@@ -226,6 +232,7 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 						e.ConnectionType = connectionType
 						e.ServerService = serviceName
 						e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+						e.ServerIsRoot = span.ParentSpanID().IsEmpty()
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(e.Dimensions, rAttributes, span.Attributes())
 					})
@@ -262,6 +269,14 @@ func (p *processor) upsertDimensions(m map[string]string, resourceAttr pcommon.M
 	}
 }
 
+func (p *processor) upsertPeerAttributes(m map[string]string, spanAttr pcommon.Map) {
+	for _, attr := range p.config.VirtualNodePeerAttributes {
+		if v, ok := findAttributeValue(attr, spanAttr); ok {
+			m[attr] = v
+		}
+	}
+}
+
 func (p *processor) onComplete(e *store.Edge) {
 	p.logger.Debug(
 		"edge completed",
@@ -282,10 +297,47 @@ func (p *processor) onExpire(e *store.Edge) {
 		zap.String("trace_id", e.TraceID.HexString()),
 	)
 	stats.Record(context.Background(), statExpiredEdges.M(1))
+
+	if len(p.config.VirtualNodePeerAttributes) == 0 {
+		return
+	}
+
+	switch {
+	case len(e.ServerService) == 0:
+		// The server isn't instrumented, name it after the peer of the client.
+		e.VirtualNode = store.ServerVirtual
+		e.ServerService = p.peerName(e.Peer)
+		e.ServerLatencySec = e.ClientLatencySec
+	case len(e.ClientService) == 0 && e.ServerIsRoot:
+		// The request comes from outside of the traced services.
+		e.VirtualNode = store.ClientVirtual
+		e.ClientService = virtualClientName
+	default:
+		return
+	}
+
+	p.logger.Debug(
+		"edge completed with a virtual node",
+		zap.String("client_service", e.ClientService),
+		zap.String("server_service", e.ServerService),
+		zap.String("virtual_node", string(e.VirtualNode)),
+		zap.String("trace_id", e.TraceID.HexString()),
+	)
+	p.aggregateMetricsForEdge(e)
+}
+
+// peerName returns the value of the first configured peer attribute found.
+func (p *processor) peerName(peer map[string]string) string {
+	for _, attr := range p.config.VirtualNodePeerAttributes {
+		if v, ok := peer[attr]; ok && v != "" {
+			return v
+		}
+	}
+	return unknownServerName
 }
 
 func (p *processor) aggregateMetricsForEdge(e *store.Edge) {
-	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), e.Dimensions)
+	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), string(e.VirtualNode), e.Dimensions)
 	dimensions := buildDimensions(e)
 
 	// TODO: Consider configuring server or client latency
@@ -337,6 +389,9 @@ func buildDimensions(e *store.Edge) pcommon.Map {
 	dims.PutString("server", e.ServerService)
 	dims.PutString("connection_type", string(e.ConnectionType))
 	dims.PutBool("failed", e.Failed)
+	if e.VirtualNode != store.NotVirtual {
+		dims.PutString("virtual_node", string(e.VirtualNode))
+	}
 	for k, v := range e.Dimensions {
 		dims.PutString(k, v)
 	}
@@ -436,9 +491,9 @@ func (p *processor) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
-func (p *processor) buildMetricKey(clientName, serverName, connectionType string, edgeDimensions map[string]string) string {
+func (p *processor) buildMetricKey(clientName, serverName, connectionType, virtualNode string, edgeDimensions map[string]string) string {
 	var metricKey strings.Builder
-	metricKey.WriteString(clientName + metricKeySeparator + serverName + metricKeySeparator + connectionType)
+	metricKey.WriteString(clientName + metricKeySeparator + serverName + metricKeySeparator + connectionType + metricKeySeparator + virtualNode)
 
 	for _, dimName := range p.config.Dimensions {
 		dim, ok := edgeDimensions[dimName]
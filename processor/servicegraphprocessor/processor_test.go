@@ -33,6 +33,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -234,6 +236,104 @@ func sampleTraces() ptrace.Traces {
 	return traces
 }
 
+func TestProcessorVirtualNodes(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+	spanID := pcommon.SpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+	parentSpanID := pcommon.SpanID([8]byte{0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26})
+
+	for _, tc := range []struct {
+		name           string
+		peerAttributes []string
+		kind           ptrace.SpanKind
+		parentSpanID   pcommon.SpanID
+		spanAttributes map[string]string
+		wantDims       map[string]string
+	}{
+		{
+			name:           "server named after the first peer attribute",
+			peerAttributes: []string{"peer.service", "db.system"},
+			kind:           ptrace.SpanKindClient,
+			spanAttributes: map[string]string{"db.system": "redis", "peer.service": "cache"},
+			wantDims:       map[string]string{"client": "some-service", "server": "cache", "virtual_node": "server"},
+		},
+		{
+			name:           "server named after the next peer attribute",
+			peerAttributes: []string{"peer.service", "db.system"},
+			kind:           ptrace.SpanKindClient,
+			spanAttributes: map[string]string{"db.system": "redis"},
+			wantDims:       map[string]string{"client": "some-service", "server": "redis", "virtual_node": "server"},
+		},
+		{
+			name:           "server without peer attribute",
+			peerAttributes: []string{"peer.service"},
+			kind:           ptrace.SpanKindProducer,
+			wantDims:       map[string]string{"client": "some-service", "server": "unknown", "connection_type": "messaging_system", "virtual_node": "server"},
+		},
+		{
+			name:           "client of a root server span",
+			peerAttributes: []string{"peer.service"},
+			kind:           ptrace.SpanKindServer,
+			wantDims:       map[string]string{"client": "user", "server": "some-service", "virtual_node": "client"},
+		},
+		{
+			name:           "client of a server span with a parent",
+			peerAttributes: []string{"peer.service"},
+			kind:           ptrace.SpanKindServer,
+			parentSpanID:   parentSpanID,
+		},
+		{
+			name:           "virtual nodes disabled",
+			kind:           ptrace.SpanKindClient,
+			spanAttributes: map[string]string{"peer.service": "cache"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			cfg := &Config{VirtualNodePeerAttributes: tc.peerAttributes}
+			p := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+			// Edges expire right away
+			p.store = store.NewStore(-time.Second, 10, p.onComplete, p.onExpire)
+
+			td := ptrace.NewTraces()
+			rs := td.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutString(semconv.AttributeServiceName, "some-service")
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetSpanID(spanID)
+			span.SetParentSpanID(tc.parentSpanID)
+			span.SetKind(tc.kind)
+			for k, v := range tc.spanAttributes {
+				span.Attributes().PutString(k, v)
+			}
+
+			// Test
+			require.NoError(t, p.aggregateMetrics(context.Background(), td))
+			p.store.Expire()
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			// Verify
+			if tc.wantDims == nil {
+				assert.Equal(t, 0, md.MetricCount())
+				return
+			}
+			require.Equal(t, 2, md.MetricCount())
+			mCount := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "request_total", mCount.Name())
+			dp := mCount.Sum().DataPoints().At(0)
+			assert.Equal(t, int64(1), dp.IntVal())
+			if _, ok := tc.wantDims["connection_type"]; !ok {
+				tc.wantDims["connection_type"] = ""
+			}
+			tc.wantDims["failed"] = "false"
+			assert.Equal(t, len(tc.wantDims), dp.Attributes().Len())
+			for k, v := range tc.wantDims {
+				verifyAttr(t, dp.Attributes(), k, v)
+			}
+		})
+	}
+}
+
 func newOTLPExporters(t *testing.T) (*otlpexporter.Config, component.MetricsExporter, component.TracesExporter) {
 	otlpExpFactory := otlpexporter.NewFactory()
 	otlpConfig := &otlpexporter.Config{
@@ -15,11 +15,14 @@
 package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/service/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
 )
 
 const (
@@ -44,6 +47,10 @@ type Config struct {
 	// See defaultLatencyHistogramBucketsMs in processor.go for the default value.
 	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
 
+	// ExponentialHistogram makes the latency metric an exponential histogram instead of a histogram
+	// with explicit buckets, when set. It can't be used along with LatencyHistogramBuckets.
+	ExponentialHistogram *ExponentialHistogramConfig `mapstructure:"exponential_histogram"`
+
 	// Dimensions defines the list of additional dimensions on top of the provided:
 	// - service.name
 	// - operation
@@ -60,10 +67,62 @@ type Config struct {
 
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
+	// Events configures the metrics counting the events of the spans.
+	Events EventsConfig `mapstructure:"events"`
+
 	// skipSanitizeLabel if enabled, labels that start with _ are not sanitized
 	skipSanitizeLabel bool
 }
 
+// EventsConfig defines the configuration of the span event metrics.
+type EventsConfig struct {
+	// Enabled makes the processor count the events of the spans per event name, on top of the span dimensions.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dimensions defines the list of additional dimensions on top of the span dimensions and of:
+	// - event.name
+	// - exception.type
+	// The dimensions will be fetched from the event's attributes, falling back to the span's attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
+// ExponentialHistogramConfig defines the configuration of the exponential latency histogram.
+type ExponentialHistogramConfig struct {
+	// MaxSize is the maximum number of buckets of the histogram. The scale is reduced whenever
+	// the latencies recorded don't fit into this number of buckets anymore.
+	// Optional. See expohisto.DefaultMaxSize for the default value.
+	MaxSize int32 `mapstructure:"max_size"`
+
+	// MaxScale is the scale a histogram starts with, the largest scale gives the most precise buckets.
+	// Optional. See expohisto.MaxScale for the default value.
+	MaxScale *int32 `mapstructure:"max_scale"`
+}
+
+func (c ExponentialHistogramConfig) maxSize() int32 {
+	if c.MaxSize == 0 {
+		return expohisto.DefaultMaxSize
+	}
+	return c.MaxSize
+}
+
+func (c ExponentialHistogramConfig) maxScale() int32 {
+	if c.MaxScale == nil {
+		return expohisto.MaxScale
+	}
+	return *c.MaxScale
+}
+
+func (c ExponentialHistogramConfig) validate() error {
+	if size := c.maxSize(); size < expohisto.MinSize {
+		return fmt.Errorf("invalid exponential histogram max size: %v, it should be at least %v", size, expohisto.MinSize)
+	}
+	if scale := c.maxScale(); scale < expohisto.MinScale || scale > expohisto.MaxScale {
+		return fmt.Errorf("invalid exponential histogram max scale: %v, it should be between %v and %v",
+			scale, expohisto.MinScale, expohisto.MaxScale)
+	}
+	return nil
+}
+
 var dropSanitizationGate = featuregate.Gate{
 	ID:          "processor.spanmetrics.PermissiveLabelSanitization",
 	Enabled:     false,
@@ -36,6 +36,8 @@ import (
 
 func TestLoadConfig(t *testing.T) {
 	defaultMethod := "GET"
+	maxScale := int32(10)
+	defaultEscaped := "false"
 	testcases := []struct {
 		configFile                  string
 		wantMetricsExporter         string
@@ -43,6 +45,8 @@ func TestLoadConfig(t *testing.T) {
 		wantDimensions              []Dimension
 		wantDimensionsCacheSize     int
 		wantAggregationTemporality  string
+		wantExponentialHistogram    *ExponentialHistogramConfig
+		wantEvents                  EventsConfig
 	}{
 		{
 			configFile:                 "config-2-pipelines.yaml",
@@ -74,6 +78,22 @@ func TestLoadConfig(t *testing.T) {
 			},
 			wantDimensionsCacheSize:    1500,
 			wantAggregationTemporality: delta,
+			wantEvents: EventsConfig{
+				Enabled: true,
+				Dimensions: []Dimension{
+					{"exception.escaped", &defaultEscaped},
+				},
+			},
+		},
+		{
+			configFile:                 "config-exponential-histogram.yaml",
+			wantMetricsExporter:        "otlp/spanmetrics",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+			wantExponentialHistogram: &ExponentialHistogramConfig{
+				MaxSize:  80,
+				MaxScale: &maxScale,
+			},
 		},
 	}
 	for _, tc := range testcases {
@@ -106,6 +126,8 @@ func TestLoadConfig(t *testing.T) {
 					Dimensions:              tc.wantDimensions,
 					DimensionsCacheSize:     tc.wantDimensionsCacheSize,
 					AggregationTemporality:  tc.wantAggregationTemporality,
+					ExponentialHistogram:    tc.wantExponentialHistogram,
+					Events:                  tc.wantEvents,
 				},
 				cfg.Processors[config.NewComponentID(typeStr)],
 			)
@@ -33,6 +33,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/cache"
 )
 
@@ -43,6 +44,10 @@ const (
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
 	metricKeySeparator = string(byte(0))
 	traceIDKey         = "trace_id"
+	eventNameKey       = "event.name" // OpenTelemetry non-standard constant.
+	exceptionTypeKey   = conventions.AttributeExceptionType
+	// eventKeyPrefix starts the key of event metrics, so that it never matches the key of a span metric.
+	eventKeyPrefix = string(byte(1))
 
 	defaultDimensionsCacheSize = 1000
 )
@@ -77,6 +82,9 @@ type processorImp struct {
 	// Call & Error counts.
 	callSum map[metricKey]int64
 
+	// Event counts.
+	eventSum map[metricKey]int64
+
 	// Latency histogram.
 	latencyCount         map[metricKey]uint64
 	latencySum           map[metricKey]float64
@@ -84,6 +92,9 @@ type processorImp struct {
 	latencyBounds        []float64
 	latencyExemplarsData map[metricKey][]exemplarData
 
+	// Exponential latency histogram, used instead of the explicit bucket counts when configured.
+	latencyExpHistograms map[metricKey]*expohisto.Histogram
+
 	// An LRU cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache
@@ -98,9 +109,27 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
 	}
 
+	if pConfig.ExponentialHistogram != nil {
+		if pConfig.LatencyHistogramBuckets != nil {
+			return nil, fmt.Errorf("latency_histogram_buckets can't be used along with exponential_histogram")
+		}
+		if err := pConfig.ExponentialHistogram.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := validateDimensions(pConfig.Dimensions, pConfig.skipSanitizeLabel); err != nil {
 		return nil, err
 	}
+	if pConfig.Events.Enabled {
+		// Event metrics have the span dimensions too, so that all of them have to be unique.
+		eventDimensions := []Dimension{{Name: eventNameKey}, {Name: exceptionTypeKey}}
+		eventDimensions = append(eventDimensions, pConfig.Dimensions...)
+		eventDimensions = append(eventDimensions, pConfig.Events.Dimensions...)
+		if err := validateDimensions(eventDimensions, pConfig.skipSanitizeLabel); err != nil {
+			return nil, fmt.Errorf("invalid event dimensions: %w", err)
+		}
+	}
 
 	if pConfig.DimensionsCacheSize <= 0 {
 		return nil, fmt.Errorf(
@@ -118,11 +147,13 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		config:                *pConfig,
 		startTime:             time.Now(),
 		callSum:               make(map[metricKey]int64),
+		eventSum:              make(map[metricKey]int64),
 		latencyBounds:         bounds,
 		latencySum:            make(map[metricKey]float64),
 		latencyCount:          make(map[metricKey]uint64),
 		latencyBucketCounts:   make(map[metricKey][]uint64),
 		latencyExemplarsData:  make(map[metricKey][]exemplarData),
+		latencyExpHistograms:  make(map[metricKey]*expohisto.Histogram),
 		nextConsumer:          nextConsumer,
 		dimensions:            pConfig.Dimensions,
 		metricKeyToDimensions: metricKeyToDimensionsCache,
@@ -264,6 +295,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 		return pmetric.Metrics{}, err
 	}
 
+	if err := p.collectEventMetrics(ilm); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
 	p.metricKeyToDimensions.RemoveEvictedItems()
 
 	// If delta metrics, reset accumulated data
@@ -278,6 +313,9 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 // collectLatencyMetrics collects the raw latency metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	if p.config.ExponentialHistogram != nil {
+		return p.collectLatencyExpHistogramMetrics(ilm)
+	}
 	for key := range p.latencyCount {
 		mLatency := ilm.Metrics().AppendEmpty()
 		mLatency.SetName("latency")
@@ -307,6 +345,35 @@ func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectLatencyExpHistogramMetrics collects the exponential latency histograms, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectLatencyExpHistogramMetrics(ilm pmetric.ScopeMetrics) error {
+	for key, histogram := range p.latencyExpHistograms {
+		mLatency := ilm.Metrics().AppendEmpty()
+		mLatency.SetName("latency")
+		mLatency.SetUnit("ms")
+		mLatency.SetEmptyExponentialHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+		dpLatency := mLatency.ExponentialHistogram().DataPoints().AppendEmpty()
+		dpLatency.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpLatency.SetTimestamp(timestamp)
+		histogram.CopyTo(dpLatency)
+
+		setLatencyExemplars(p.latencyExemplarsData[key], timestamp, dpLatency.Exemplars())
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			p.logger.Error(err.Error())
+			return err
+		}
+
+		dimensions.CopyTo(dpLatency.Attributes())
+	}
+	return nil
+}
+
 // collectCallMetrics collects the raw call count metrics, writing the data
 // into the given instrumentation library metrics.
 func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
@@ -331,6 +398,30 @@ func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectEventMetrics collects the raw event count metrics, writing the data
+// into the given instrumentation library metrics.
+func (p *processorImp) collectEventMetrics(ilm pmetric.ScopeMetrics) error {
+	for key := range p.eventSum {
+		mEvents := ilm.Metrics().AppendEmpty()
+		mEvents.SetName("events_total")
+		mEvents.SetEmptySum().SetIsMonotonic(true)
+		mEvents.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+		dpEvents := mEvents.Sum().DataPoints().AppendEmpty()
+		dpEvents.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpEvents.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpEvents.SetIntVal(p.eventSum[key])
+
+		dimensions, err := p.getDimensionsByMetricKey(key)
+		if err != nil {
+			return err
+		}
+
+		dimensions.CopyTo(dpEvents.Attributes())
+	}
+	return nil
+}
+
 // getDimensionsByMetricKey gets dimensions from `metricKeyToDimensions` cache.
 func (p *processorImp) getDimensionsByMetricKey(k metricKey) (*pcommon.Map, error) {
 	if item, ok := p.metricKeyToDimensions.Get(k); ok {
@@ -382,15 +473,37 @@ func (p *processorImp) aggregateMetricsForSpan(serviceName string, span ptrace.S
 		latencyInMilliseconds = float64(endTime-startTime) / float64(time.Millisecond.Nanoseconds())
 	}
 
-	// Binary search to find the latencyInMilliseconds bucket index.
-	index := sort.SearchFloat64s(p.latencyBounds, latencyInMilliseconds)
-
 	key := buildKey(serviceName, span, p.dimensions, resourceAttr)
 
 	p.cache(serviceName, span, key, resourceAttr)
 	p.updateCallMetrics(key)
-	p.updateLatencyMetrics(key, latencyInMilliseconds, index)
+	if p.config.ExponentialHistogram != nil {
+		p.updateLatencyExpHistogram(key, latencyInMilliseconds)
+	} else {
+		// Binary search to find the latencyInMilliseconds bucket index.
+		index := sort.SearchFloat64s(p.latencyBounds, latencyInMilliseconds)
+		p.updateLatencyMetrics(key, latencyInMilliseconds, index)
+	}
 	p.updateLatencyExemplars(key, latencyInMilliseconds, span.TraceID())
+
+	if p.config.Events.Enabled {
+		p.aggregateEventMetrics(serviceName, span, key, resourceAttr)
+	}
+}
+
+// aggregateEventMetrics counts the events of the span, per event name and span metric key.
+func (p *processorImp) aggregateEventMetrics(serviceName string, span ptrace.Span, spanKey metricKey, resourceAttr pcommon.Map) {
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		key := buildEventKey(spanKey, event, p.config.Events.Dimensions, span.Attributes())
+		if _, has := p.metricKeyToDimensions.Get(key); !has {
+			dims := p.buildDimensionKVs(serviceName, span, p.dimensions, resourceAttr)
+			addEventDimensionKVs(dims, event, p.config.Events.Dimensions, span.Attributes())
+			p.metricKeyToDimensions.Add(key, dims)
+		}
+		p.eventSum[key]++
+	}
 }
 
 // updateCallMetrics increments the call count for the given metric key.
@@ -402,9 +515,11 @@ func (p *processorImp) updateCallMetrics(key metricKey) {
 // metricKeyToDimensions.
 func (p *processorImp) resetAccumulatedMetrics() {
 	p.callSum = make(map[metricKey]int64)
+	p.eventSum = make(map[metricKey]int64)
 	p.latencyCount = make(map[metricKey]uint64)
 	p.latencySum = make(map[metricKey]float64)
 	p.latencyBucketCounts = make(map[metricKey][]uint64)
+	p.latencyExpHistograms = make(map[metricKey]*expohisto.Histogram)
 	p.metricKeyToDimensions.Purge()
 }
 
@@ -438,6 +553,16 @@ func (p *processorImp) updateLatencyMetrics(key metricKey, latency float64, inde
 	p.latencyBucketCounts[key][index]++
 }
 
+// updateLatencyExpHistogram records the latency in the exponential histogram of the given metric key.
+func (p *processorImp) updateLatencyExpHistogram(key metricKey, latency float64) {
+	histogram, ok := p.latencyExpHistograms[key]
+	if !ok {
+		histogram = expohisto.New(p.config.ExponentialHistogram.maxSize(), p.config.ExponentialHistogram.maxScale())
+		p.latencyExpHistograms[key] = histogram
+	}
+	histogram.Update(latency)
+}
+
 func (p *processorImp) buildDimensionKVs(serviceName string, span ptrace.Span, optionalDims []Dimension, resourceAttrs pcommon.Map) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.PutString(serviceNameKey, serviceName)
@@ -483,6 +608,39 @@ func buildKey(serviceName string, span ptrace.Span, optionalDims []Dimension, re
 	return k
 }
 
+// buildEventKey builds the metric key of an event from the key of its span, the event name, the exception type
+// and any additional event dimensions the user has configured that match the event's attributes or span's attributes.
+func buildEventKey(spanKey metricKey, event ptrace.SpanEvent, eventDims []Dimension, spanAttrs pcommon.Map) metricKey {
+	var metricKeyBuilder strings.Builder
+	metricKeyBuilder.WriteString(eventKeyPrefix)
+	metricKeyBuilder.WriteString(string(spanKey))
+	concatDimensionValue(&metricKeyBuilder, event.Name(), true)
+	if v, ok := event.Attributes().Get(exceptionTypeKey); ok {
+		concatDimensionValue(&metricKeyBuilder, v.AsString(), true)
+	}
+
+	for _, d := range eventDims {
+		if v, ok := getDimensionValue(d, event.Attributes(), spanAttrs); ok {
+			concatDimensionValue(&metricKeyBuilder, v.AsString(), true)
+		}
+	}
+
+	return metricKey(metricKeyBuilder.String())
+}
+
+// addEventDimensionKVs adds the event name, exception type and additional event dimensions to the span dimensions.
+func addEventDimensionKVs(dims pcommon.Map, event ptrace.SpanEvent, eventDims []Dimension, spanAttrs pcommon.Map) {
+	dims.PutString(eventNameKey, event.Name())
+	if v, ok := event.Attributes().Get(exceptionTypeKey); ok {
+		v.CopyTo(dims.PutEmpty(exceptionTypeKey))
+	}
+	for _, d := range eventDims {
+		if v, ok := getDimensionValue(d, event.Attributes(), spanAttrs); ok {
+			v.CopyTo(dims.PutEmpty(d.Name))
+		}
+	}
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
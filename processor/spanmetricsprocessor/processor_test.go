@@ -37,6 +37,7 @@ import (
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor/mocks"
 )
@@ -165,6 +166,56 @@ func TestConfigureLatencyBounds(t *testing.T) {
 	assert.Equal(t, []float64{0.000003, 0.003, 3, 3000}, p.latencyBounds)
 }
 
+func TestConfigureExponentialHistogram(t *testing.T) {
+	invalidScale := int32(expohisto.MaxScale + 1)
+	for _, tc := range []struct {
+		name        string
+		buckets     []time.Duration
+		histogram   ExponentialHistogramConfig
+		expectedErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:        "with latency buckets",
+			buckets:     []time.Duration{time.Millisecond},
+			expectedErr: "latency_histogram_buckets can't be used along with exponential_histogram",
+		},
+		{
+			name:        "max size too small",
+			histogram:   ExponentialHistogramConfig{MaxSize: 1},
+			expectedErr: "invalid exponential histogram max size: 1, it should be at least 2",
+		},
+		{
+			name:        "max scale too large",
+			histogram:   ExponentialHistogramConfig{MaxScale: &invalidScale},
+			expectedErr: "invalid exponential histogram max scale: 21, it should be between -10 and 20",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.LatencyHistogramBuckets = tc.buckets
+			cfg.ExponentialHistogram = &tc.histogram
+
+			// Test
+			next := new(consumertest.TracesSink)
+			p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+
+			// Verify
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Nil(t, p)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, p)
+		})
+	}
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -311,6 +362,197 @@ func TestProcessorConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestProcessorExponentialHistogram(t *testing.T) {
+	for _, tc := range []struct {
+		name                   string
+		aggregationTemporality string
+		wantCount              uint64
+	}{
+		{
+			name:                   "cumulative",
+			aggregationTemporality: cumulative,
+			wantCount:              2,
+		},
+		{
+			name:                   "delta",
+			aggregationTemporality: delta,
+			wantCount:              1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			p := newProcessorImp(nil, nil, nil, tc.aggregationTemporality, zaptest.NewLogger(t))
+			p.config.ExponentialHistogram = &ExponentialHistogramConfig{}
+
+			// Test
+			var m pmetric.Metrics
+			for i := 0; i < 2; i++ {
+				p.aggregateMetrics(buildSampleTrace())
+				var err error
+				m, err = p.buildMetrics()
+				require.NoError(t, err)
+			}
+
+			// Verify
+			metrics := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 6, metrics.Len())
+			for i := 3; i < metrics.Len(); i++ {
+				metric := metrics.At(i)
+				assert.Equal(t, "latency", metric.Name())
+				assert.Equal(t, "ms", metric.Unit())
+				require.Equal(t, pmetric.MetricDataTypeExponentialHistogram, metric.DataType())
+
+				data := metric.ExponentialHistogram()
+				assert.Equal(t, p.config.GetAggregationTemporality(), data.AggregationTemporality())
+				require.Equal(t, 1, data.DataPoints().Len())
+
+				dp := data.DataPoints().At(0)
+				assert.Equal(t, tc.wantCount, dp.Count())
+				assert.Equal(t, sampleLatency*float64(tc.wantCount), dp.Sum())
+				assert.Equal(t, sampleLatency, dp.Min())
+				assert.Equal(t, sampleLatency, dp.Max())
+				assert.Equal(t, int32(expohisto.MaxScale), dp.Scale())
+				assert.Equal(t, []uint64{tc.wantCount}, dp.Positive().BucketCounts().AsRaw())
+				assert.NotZero(t, dp.StartTimestamp(), "StartTimestamp should be set")
+				assert.NotZero(t, dp.Timestamp(), "Timestamp should be set")
+
+				_, ok := dp.Attributes().Get(serviceNameKey)
+				assert.True(t, ok)
+			}
+		})
+	}
+}
+
+func TestProcessorEventMetrics(t *testing.T) {
+	// Prepare
+	p := newProcessorImp(nil, nil, nil, cumulative, zaptest.NewLogger(t))
+	defaultErrorCode := "none"
+	p.config.Events = EventsConfig{
+		Enabled: true,
+		Dimensions: []Dimension{
+			{Name: "error.code", Default: &defaultErrorCode},
+			{Name: stringAttrName},
+		},
+	}
+
+	traces := ptrace.NewTraces()
+	initServiceSpans(
+		serviceSpans{
+			serviceName: "service-a",
+			spans: []span{
+				{
+					operation:  "/ping",
+					kind:       ptrace.SpanKindServer,
+					statusCode: ptrace.StatusCodeError,
+				},
+			},
+		}, traces.ResourceSpans().AppendEmpty())
+	s := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	for i := 0; i < 2; i++ {
+		exception := s.Events().AppendEmpty()
+		exception.SetName("exception")
+		exception.Attributes().PutString(conventions.AttributeExceptionType, "java.io.IOException")
+		exception.Attributes().PutString("error.code", "E42")
+	}
+	s.Events().AppendEmpty().SetName("retry")
+
+	// Test
+	p.aggregateMetrics(traces)
+	m, err := p.buildMetrics()
+	require.NoError(t, err)
+
+	// Verify
+	metrics := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, metrics.Len(), "expected one call, one latency and two event metrics")
+
+	events := make(map[string]pmetric.NumberDataPoint)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != "events_total" {
+			continue
+		}
+		assert.True(t, metric.Sum().IsMonotonic())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+		require.Equal(t, 1, metric.Sum().DataPoints().Len())
+		dp := metric.Sum().DataPoints().At(0)
+		name, ok := dp.Attributes().Get(eventNameKey)
+		require.True(t, ok)
+		events[name.StringVal()] = dp
+	}
+	require.Len(t, events, 2)
+
+	exception := events["exception"]
+	assert.Equal(t, int64(2), exception.IntVal())
+	assert.Equal(t, map[string]interface{}{
+		serviceNameKey:         "service-a",
+		operationKey:           "/ping",
+		spanKindKey:            "SPAN_KIND_SERVER",
+		statusCodeKey:          "STATUS_CODE_ERROR",
+		eventNameKey:           "exception",
+		exceptionTypeKey:       "java.io.IOException",
+		"error.code":           "E42",
+		stringAttrName:         "stringAttrValue",
+		intAttrName:            int64(99),
+		doubleAttrName:         99.99,
+		boolAttrName:           true,
+		nullAttrName:           nil,
+		mapAttrName:            map[string]interface{}{},
+		arrayAttrName:          []interface{}{},
+		regionResourceAttrName: sampleRegion,
+		notInSpanAttrName0:     "defaultNotInSpanAttrVal",
+	}, exception.Attributes().AsRaw())
+
+	retry := events["retry"]
+	assert.Equal(t, int64(1), retry.IntVal())
+	_, ok := retry.Attributes().Get(exceptionTypeKey)
+	assert.False(t, ok)
+	errorCode, ok := retry.Attributes().Get("error.code")
+	require.True(t, ok)
+	assert.Equal(t, "none", errorCode.StringVal())
+}
+
+func TestProcessorInvalidEventDimensions(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		dimensions      []Dimension
+		eventDimensions []Dimension
+	}{
+		{
+			name:            "reserved event dimension",
+			eventDimensions: []Dimension{{Name: eventNameKey}},
+		},
+		{
+			name:            "reserved event dimension after sanitization",
+			eventDimensions: []Dimension{{Name: "exception_type"}},
+		},
+		{
+			name:            "event dimension duplicating a span dimension",
+			dimensions:      []Dimension{{Name: "http.method"}},
+			eventDimensions: []Dimension{{Name: "http.method"}},
+		},
+		{
+			name:       "span dimension reserved for events",
+			dimensions: []Dimension{{Name: exceptionTypeKey}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Dimensions = tc.dimensions
+			cfg.Events = EventsConfig{Enabled: true, Dimensions: tc.eventDimensions}
+
+			// Test
+			next := new(consumertest.TracesSink)
+			p, err := newProcessor(zaptest.NewLogger(t), cfg, next)
+
+			// Verify
+			assert.ErrorContains(t, err, "invalid event dimensions")
+			assert.Nil(t, p)
+		})
+	}
+}
+
 func TestMetricKeyCache(t *testing.T) {
 	mexp := &mocks.MetricsExporter{}
 	tcon := &mocks.TracesConsumer{}
@@ -381,11 +623,13 @@ func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, de
 
 		startTime:            time.Now(),
 		callSum:              make(map[metricKey]int64),
+		eventSum:             make(map[metricKey]int64),
 		latencySum:           make(map[metricKey]float64),
 		latencyCount:         make(map[metricKey]uint64),
 		latencyBucketCounts:  make(map[metricKey][]uint64),
 		latencyBounds:        defaultLatencyHistogramBucketsMs,
 		latencyExemplarsData: make(map[metricKey][]exemplarData),
+		latencyExpHistograms: make(map[metricKey]*expohisto.Histogram),
 		dimensions: []Dimension{
 			// Set nil defaults to force a lookup for the attribute in the span.
 			{stringAttrName, nil},
@@ -48,6 +48,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// TQLCondition sample traces with spans or span events matching telemetry query language conditions.
+	TQLCondition PolicyType = "tql_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -73,6 +75,8 @@ type sharedPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for defining tql_condition policy
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -194,6 +198,20 @@ type SpanCountCfg struct {
 	MinSpans int32 `mapstructure:"min_spans"`
 }
 
+// TQLConditionCfg holds the configurable settings to create a telemetry query language
+// condition filter sampling policy evaluator.
+type TQLConditionCfg struct {
+	// Match is either "any" to sample a trace if any of its spans matches, or "all"
+	// to sample a trace only if all of its spans match. Defaults to "any".
+	Match string `mapstructure:"match"`
+	// SpanConditions are evaluated against every span of the trace. A span matches if any
+	// of the conditions is true.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are evaluated against every event of every span of the trace.
+	// A span matches if any of the conditions is true for any of its events.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -209,4 +227,8 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// StorageID is the ID of a storage extension that keeps the spans of traces waiting
+	// for a sampling decision, instead of keeping them in memory. Pending traces are
+	// restored from the storage when the processor starts.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
@@ -108,6 +108,21 @@ func TestLoadConfig(t *testing.T) {
 						TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-10",
+						Type: TQLCondition,
+						TQLConditionCfg: TQLConditionCfg{
+							Match: "any",
+							SpanConditions: []string{
+								`attributes["http.status_code"] >= 500 and resource.attributes["service.name"] == "checkout"`,
+							},
+							SpanEventConditions: []string{
+								`name == "exception"`,
+							},
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -177,3 +192,35 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestLoadConfigWithStorage(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "tail_sampling_config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "storage").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+	storageID := config.NewComponentID("file_storage")
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+			DecisionWait:      5 * time.Minute,
+			NumTraces:         1000000,
+			StorageID:         &storageID,
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-1",
+						Type: AlwaysSample,
+					},
+				},
+			},
+		},
+		cfg)
+}
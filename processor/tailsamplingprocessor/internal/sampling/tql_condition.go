@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlotel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// TQLMatchMode determines how the results for the individual spans of a trace
+// are combined into a sampling decision.
+type TQLMatchMode string
+
+const (
+	// TQLMatchAny samples a trace if any of its spans matches.
+	TQLMatchAny TQLMatchMode = "any"
+	// TQLMatchAll samples a trace only if all of its spans match.
+	TQLMatchAll TQLMatchMode = "all"
+)
+
+// tqlFunctions are the functions that can be used within conditions. Functions
+// that modify telemetry are left out on purpose, since a policy must not change
+// the traces it evaluates.
+var tqlFunctions = map[string]interface{}{
+	"TraceID": tqlotel.TraceID,
+	"SpanID":  tqlotel.SpanID,
+	"IsMatch": tqlcommon.IsMatch,
+	"Concat":  tqlcommon.Concat,
+	"Split":   tqlotel.Split,
+	"Int":     tqlcommon.Int,
+}
+
+type tqlConditionFilter struct {
+	logger              *zap.Logger
+	matchAll            bool
+	spanConditions      []tql.Condition
+	spanEventConditions []tql.Condition
+}
+
+var _ PolicyEvaluator = (*tqlConditionFilter)(nil)
+
+// NewTQLConditionFilter creates a policy evaluator that samples traces based on
+// telemetry query language conditions. A span matches if any of the span
+// conditions is true for it, or if any of the span event conditions is true for
+// one of its events. Span conditions can also refer to the resource and
+// instrumentation scope of the span.
+func NewTQLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string, mode TQLMatchMode) (PolicyEvaluator, error) {
+	filter := &tqlConditionFilter{
+		logger: logger,
+	}
+
+	switch mode {
+	case "", TQLMatchAny:
+	case TQLMatchAll:
+		filter.matchAll = true
+	default:
+		return nil, fmt.Errorf("unknown match mode %q, must be %q or %q", mode, TQLMatchAny, TQLMatchAll)
+	}
+
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errors.New("at least one span or span event condition is required")
+	}
+
+	var err error
+	spanParser := tql.NewParser(tqlFunctions, tqltraces.ParsePath, tqltraces.ParseEnum, tql.NoOpLogger{})
+	if filter.spanConditions, err = spanParser.ParseConditions(spanConditions); err != nil {
+		return nil, fmt.Errorf("invalid span conditions: %w", err)
+	}
+	spanEventParser := tql.NewParser(tqlFunctions, tqlspanevents.ParsePath, tqlspanevents.ParseEnum, tql.NoOpLogger{})
+	if filter.spanEventConditions, err = spanEventParser.ParseConditions(spanEventConditions); err != nil {
+		return nil, fmt.Errorf("invalid span event conditions: %w", err)
+	}
+
+	return filter, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tcf *tqlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	tcf.logger.Debug("Evaluating spans in tql condition filter")
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	matchedAny := false
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			resource := rs.Resource()
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				scope := ils.Scope()
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					matched := tcf.matchSpan(spans.At(k), scope, resource)
+					switch {
+					case matched && !tcf.matchAll:
+						return Sampled, nil
+					case !matched && tcf.matchAll:
+						return NotSampled, nil
+					}
+					matchedAny = matchedAny || matched
+				}
+			}
+		}
+	}
+
+	if matchedAny {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (tcf *tqlConditionFilter) matchSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	if len(tcf.spanConditions) > 0 {
+		ctx := tqltraces.NewTransformContext(span, scope, resource)
+		for _, condition := range tcf.spanConditions {
+			if condition(ctx) {
+				return true
+			}
+		}
+	}
+
+	if len(tcf.spanEventConditions) > 0 {
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			ctx := tqlspanevents.NewTransformContext(events.At(i), span, scope, resource)
+			for _, condition := range tcf.spanEventConditions {
+				if condition(ctx) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestTQLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Mode                TQLMatchMode
+		Trace               *TraceData
+		Decision            Decision
+	}{
+		{
+			Desc:           "matching span attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 200), newTQLTestSpan("b", 503)),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching span attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 200), newTQLTestSpan("b", 404)),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "matching span and resource attributes",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500 and resource.attributes["service.name"] == "checkout"`},
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 503)),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching resource attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500 and resource.attributes["service.name"] == "cart"`},
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 503)),
+			Decision:       NotSampled,
+		},
+		{
+			Desc: "any of multiple conditions",
+			SpanConditions: []string{
+				`name == "c"`,
+				`IsMatch(name, "^b") == true`,
+			},
+			Trace:    newTQLTestTrace(newTQLTestSpan("a", 200), newTQLTestSpan("b", 200)),
+			Decision: Sampled,
+		},
+		{
+			Desc:           "all spans match",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Mode:           TQLMatchAll,
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 500), newTQLTestSpan("b", 503)),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "not all spans match",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Mode:           TQLMatchAll,
+			Trace:          newTQLTestTrace(newTQLTestSpan("a", 200), newTQLTestSpan("b", 503)),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "all spans match on empty trace",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Mode:           TQLMatchAll,
+			Trace:          newTQLTestTrace(),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:                "matching span event",
+			SpanEventConditions: []string{`name == "exception" and attributes["exception.type"] == "timeout"`},
+			Trace:               newTQLTestTrace(newTQLTestSpan("a", 200), newTQLTestSpan("b", 200, "timeout")),
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "nonmatching span event",
+			SpanEventConditions: []string{`name == "exception" and attributes["exception.type"] == "timeout"`},
+			Trace:               newTQLTestTrace(newTQLTestSpan("a", 200, "canceled")),
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "span event refers to its span",
+			SpanEventConditions: []string{`name == "exception" and span.name == "b"`},
+			Trace:               newTQLTestTrace(newTQLTestSpan("a", 200, "timeout"), newTQLTestSpan("b", 200, "timeout")),
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "all spans match either span or span event conditions",
+			SpanConditions:      []string{`attributes["http.status_code"] >= 500`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Mode:                TQLMatchAll,
+			Trace:               newTQLTestTrace(newTQLTestSpan("a", 200, "timeout"), newTQLTestSpan("b", 503)),
+			Decision:            Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewTQLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions, c.Mode)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestTQLConditionFilterInvalid(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Mode                TQLMatchMode
+	}{
+		{
+			Desc: "no conditions",
+		},
+		{
+			Desc:           "unknown mode",
+			SpanConditions: []string{`name == "a"`},
+			Mode:           "some",
+		},
+		{
+			Desc:           "invalid span condition",
+			SpanConditions: []string{`name = "a"`},
+		},
+		{
+			Desc:           "unknown span path",
+			SpanConditions: []string{`unknown == "a"`},
+		},
+		{
+			Desc:                "invalid span event condition",
+			SpanEventConditions: []string{`kind == SPAN_KIND_SERVER`},
+		},
+		{
+			Desc:           "functions that modify telemetry are not available",
+			SpanConditions: []string{`set(name, "a") where name == "b"`},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			_, err := NewTQLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions, c.Mode)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newTQLTestSpan(name string, statusCode int64, exceptionTypes ...string) ptrace.Span {
+	span := ptrace.NewSpan()
+	span.SetName(name)
+	span.Attributes().PutInt("http.status_code", statusCode)
+	for _, exceptionType := range exceptionTypes {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutString("exception.type", exceptionType)
+	}
+	return span
+}
+
+func newTQLTestTrace(spans ...ptrace.Span) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutString("service.name", "checkout")
+	ils := rs.ScopeSpans().AppendEmpty()
+	for _, span := range spans {
+		span.CopyTo(ils.Spans().AppendEmpty())
+	}
+	return &TraceData{
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -60,6 +61,11 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	id              config.ComponentID
+	storageID       *config.ComponentID
+	// storage keeps the spans of pending traces when a storage extension is
+	// configured, otherwise they are kept in memory.
+	storage *traceStorage
 }
 
 const (
@@ -108,6 +114,8 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+		id:              cfg.ID(),
+		storageID:       cfg.StorageID,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -155,6 +163,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tqlCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tqlCfg.SpanConditions, tqlCfg.SpanEventConditions, sampling.TQLMatchMode(tqlCfg.Match))
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -180,6 +191,10 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
+		if tsp.storage != nil {
+			tsp.loadStoredBatches(id, trace)
+		}
+
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
 
 		// Sampled or not, remove the batches
@@ -202,6 +217,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 	}
 
+	if tsp.storage != nil {
+		if err := tsp.storage.flush(tsp.ctx); err != nil {
+			tsp.logger.Warn("Failed to store the list of pending traces", zap.Error(err))
+		}
+	}
+
 	stats.Record(tsp.ctx,
 		statOverallDecisionLatencyUs.M(int64(time.Since(startTime)/time.Microsecond)),
 		statDroppedTooEarlyCount.M(metrics.idNotFoundOnMapCount),
@@ -217,6 +238,18 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	)
 }
 
+// loadStoredBatches moves the batches of the trace from the storage to memory,
+// so the policies can evaluate them.
+func (tsp *tailSamplingSpanProcessor) loadStoredBatches(id pcommon.TraceID, trace *sampling.TraceData) {
+	trace.Lock()
+	defer trace.Unlock()
+	batches, err := tsp.storage.load(tsp.ctx, id)
+	if err != nil {
+		tsp.logger.Warn("Failed to load spans from storage", zap.String("id", id.HexString()), zap.Error(err))
+	}
+	trace.ReceivedBatches = append(batches, trace.ReceivedBatches...)
+}
+
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
 	finalDecision := sampling.NotSampled
 	var matchingPolicy *policy
@@ -348,19 +381,12 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			actualData.SpanCount.Add(lenSpans)
 		} else {
 			newTraceIDs++
+			if tsp.storage != nil {
+				tsp.storage.track(id, initialTraceData.ArrivalTime)
+			}
 			tsp.decisionBatcher.AddToCurrentBatch(id)
 			tsp.numTracesOnMap.Add(1)
-			postDeletion := false
-			currTime := time.Now()
-			for !postDeletion {
-				select {
-				case tsp.deleteChan <- id:
-					postDeletion = true
-				default:
-					traceKeyToDrop := <-tsp.deleteChan
-					tsp.dropTrace(traceKeyToDrop, currTime)
-				}
-			}
+			tsp.enqueueForDeletion(id)
 		}
 
 		for i, p := range tsp.policies {
@@ -373,7 +399,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				// Add the spans to the trace, but only once for all policy, otherwise same spans will
 				// be duplicated in the final trace.
 				traceTd = prepareTraceBatch(resourceSpans, spans)
-				actualData.ReceivedBatches = append(actualData.ReceivedBatches, traceTd)
+				if tsp.storage == nil || !tsp.storage.appendBatch(tsp.ctx, id, traceTd) {
+					actualData.ReceivedBatches = append(actualData.ReceivedBatches, traceTd)
+				}
 				actualData.Unlock()
 				break
 			}
@@ -412,16 +440,74 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// enqueueForDeletion adds the trace to the queue of traces to drop once the
+// maximum number of traces is reached, dropping the oldest ones if needed.
+func (tsp *tailSamplingSpanProcessor) enqueueForDeletion(id pcommon.TraceID) {
+	postDeletion := false
+	currTime := time.Now()
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.id)
+		if err != nil {
+			return err
+		}
+		tsp.storage = newTraceStorage(client, tsp.logger)
+		if err = tsp.restoreTraces(ctx); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
+// restoreTraces adds the traces that were pending in the storage when the
+// processor was last shut down. Their decision is made once the decision wait
+// elapsed again.
+func (tsp *tailSamplingSpanProcessor) restoreTraces(ctx context.Context) error {
+	traces, err := tsp.storage.restore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore pending traces: %w", err)
+	}
+	lenPolicies := len(tsp.policies)
+	for _, st := range traces {
+		decisions := make([]sampling.Decision, lenPolicies)
+		for i := 0; i < lenPolicies; i++ {
+			decisions[i] = sampling.Pending
+		}
+		tsp.idToTrace.Store(st.id, &sampling.TraceData{
+			Decisions:   decisions,
+			ArrivalTime: st.arrivalTime,
+			SpanCount:   atomic.NewInt64(st.spanCount),
+		})
+		tsp.decisionBatcher.AddToCurrentBatch(st.id)
+		tsp.numTracesOnMap.Add(1)
+		tsp.enqueueForDeletion(st.id)
+	}
+	if len(traces) > 0 {
+		tsp.logger.Info("Restored pending traces from storage", zap.Int("traces", len(traces)))
+	}
+	return nil
+}
+
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.storage != nil {
+		return tsp.storage.close(ctx)
+	}
 	return nil
 }
 
@@ -432,6 +518,9 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		tsp.idToTrace.Delete(traceID)
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
+		if tsp.storage != nil {
+			tsp.storage.remove(tsp.ctx, traceID)
+		}
 	}
 	if trace == nil {
 		tsp.logger.Error("Attempt to delete traceID not on table")
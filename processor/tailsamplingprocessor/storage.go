@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// pendingTracesKey is the key of the list of traces waiting for a decision.
+	pendingTracesKey = "pending_traces"
+	// pendingTraceSize is the encoded size of one entry of the list: the trace ID,
+	// the arrival time, the number of stored batches and the number of spans.
+	pendingTraceSize = 16 + 8 + 4 + 8
+)
+
+var (
+	tracesMarshaler   = ptrace.NewProtoMarshaler()
+	tracesUnmarshaler = ptrace.NewProtoUnmarshaler()
+
+	errInvalidPendingTraces = errors.New("invalid list of pending traces in storage")
+)
+
+// storedTrace describes a trace whose batches are kept in the storage.
+type storedTrace struct {
+	id          pcommon.TraceID
+	arrivalTime time.Time
+	numBatches  uint32
+	spanCount   int64
+}
+
+// traceStorage keeps the spans of traces waiting for a sampling decision in a
+// storage extension rather than in memory. Every batch received for a trace is
+// stored under its own key. The list of pending traces is held in memory and
+// written to the storage on flush, so that the traces can be restored after a
+// restart.
+type traceStorage struct {
+	client storage.Client
+	logger *zap.Logger
+
+	// mu guards pending and dirty, and serializes the writes to the client so
+	// that a batch is never written for a trace that was just removed.
+	mu      sync.Mutex
+	pending map[pcommon.TraceID]*storedTrace
+	dirty   bool
+}
+
+func newTraceStorage(client storage.Client, logger *zap.Logger) *traceStorage {
+	return &traceStorage{
+		client:  client,
+		logger:  logger,
+		pending: make(map[pcommon.TraceID]*storedTrace),
+	}
+}
+
+// getStorageClient returns a client of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func batchKey(id pcommon.TraceID, n uint32) string {
+	return "trace_" + id.HexString() + "_" + strconv.FormatUint(uint64(n), 10)
+}
+
+// track starts keeping the batches of a new trace in the storage.
+func (s *traceStorage) track(id pcommon.TraceID, arrivalTime time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[id] = &storedTrace{id: id, arrivalTime: arrivalTime}
+	s.dirty = true
+}
+
+// appendBatch stores a batch of spans of a trace. It returns false if the trace
+// is not tracked or the batch could not be stored, in which case the caller
+// has to keep the batch in memory. Callers must hold the lock of the trace data,
+// so that no batch is written while the trace is being loaded.
+func (s *traceStorage) appendBatch(ctx context.Context, id pcommon.TraceID, td ptrace.Traces) bool {
+	buf, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		s.logger.Warn("Failed to marshal spans, keeping them in memory", zap.Error(err))
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.pending[id]
+	if !ok {
+		return false
+	}
+	if err = s.client.Set(ctx, batchKey(id, st.numBatches), buf); err != nil {
+		s.logger.Warn("Failed to store spans, keeping them in memory", zap.Error(err))
+		return false
+	}
+	st.numBatches++
+	st.spanCount += int64(td.SpanCount())
+	s.dirty = true
+	return true
+}
+
+// load stops tracking the trace, removes its batches from the storage and
+// returns them.
+func (s *traceStorage) load(ctx context.Context, id pcommon.TraceID) ([]ptrace.Traces, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.pending[id]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.pending, id)
+	s.dirty = true
+
+	gets := make([]storage.Operation, st.numBatches)
+	ops := make([]storage.Operation, 0, 2*st.numBatches)
+	for n := uint32(0); n < st.numBatches; n++ {
+		gets[n] = storage.GetOperation(batchKey(id, n))
+		ops = append(ops, gets[n])
+	}
+	for n := uint32(0); n < st.numBatches; n++ {
+		ops = append(ops, storage.DeleteOperation(batchKey(id, n)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return nil, err
+	}
+
+	batches := make([]ptrace.Traces, 0, len(gets))
+	for _, op := range gets {
+		if op.Value == nil {
+			continue
+		}
+		td, err := tracesUnmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return batches, err
+		}
+		batches = append(batches, td)
+	}
+	return batches, nil
+}
+
+// remove stops tracking the trace and removes its batches from the storage.
+func (s *traceStorage) remove(ctx context.Context, id pcommon.TraceID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.pending[id]
+	if !ok {
+		return
+	}
+	delete(s.pending, id)
+	s.dirty = true
+
+	ops := make([]storage.Operation, st.numBatches)
+	for n := uint32(0); n < st.numBatches; n++ {
+		ops[n] = storage.DeleteOperation(batchKey(id, n))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("Failed to remove spans from storage", zap.Error(err))
+	}
+}
+
+// flush writes the list of pending traces to the storage, if it changed since
+// the last flush.
+func (s *traceStorage) flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+
+	buf := make([]byte, len(s.pending)*pendingTraceSize)
+	entry := buf
+	for _, st := range s.pending {
+		copy(entry[:16], st.id[:])
+		binary.BigEndian.PutUint64(entry[16:24], uint64(st.arrivalTime.UnixNano()))
+		binary.BigEndian.PutUint32(entry[24:28], st.numBatches)
+		binary.BigEndian.PutUint64(entry[28:36], uint64(st.spanCount))
+		entry = entry[pendingTraceSize:]
+	}
+	if err := s.client.Set(ctx, pendingTracesKey, buf); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// restore reads the list of pending traces from the storage and starts tracking
+// them again. The traces are returned ordered by arrival time.
+func (s *traceStorage) restore(ctx context.Context) ([]storedTrace, error) {
+	buf, err := s.client.Get(ctx, pendingTracesKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf)%pendingTraceSize != 0 {
+		return nil, errInvalidPendingTraces
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	traces := make([]storedTrace, 0, len(buf)/pendingTraceSize)
+	for ; len(buf) > 0; buf = buf[pendingTraceSize:] {
+		st := storedTrace{
+			arrivalTime: time.Unix(0, int64(binary.BigEndian.Uint64(buf[16:24]))),
+			numBatches:  binary.BigEndian.Uint32(buf[24:28]),
+			spanCount:   int64(binary.BigEndian.Uint64(buf[28:36])),
+		}
+		copy(st.id[:], buf[:16])
+		traces = append(traces, st)
+		s.pending[st.id] = &st
+	}
+	sort.SliceStable(traces, func(i, j int) bool {
+		return traces[i].arrivalTime.Before(traces[j].arrivalTime)
+	})
+	return traces, nil
+}
+
+// close flushes the list of pending traces and closes the client.
+func (s *traceStorage) close(ctx context.Context) error {
+	err := s.flush(ctx)
+	if closeErr := s.client.Close(ctx); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newStorageTestProcessor(t *testing.T, storageID config.ComponentID, numTraces uint64, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:            defaultTestDecisionWait,
+		NumTraces:               numTraces,
+		ExpectedNewTracesPerSec: 64,
+		PolicyCfgs:              testPolicy,
+		StorageID:               &storageID,
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), sink, cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	// Control the decisions explicitly, see TestMultipleBatchesAreCombinedIntoOne.
+	tsp.decisionBatcher.Stop()
+	tsp.decisionBatcher = newSyncIDBatcher(1)
+	tsp.policyTicker = &manualTTicker{}
+	return tsp
+}
+
+func TestStorageSpansAreNotKeptInMemory(t *testing.T) {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, ext.ID(), 100, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceIds, batches := generateIdsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	for i, id := range traceIds {
+		d, ok := tsp.idToTrace.Load(id)
+		require.True(t, ok)
+		trace := d.(*sampling.TraceData)
+		assert.Empty(t, trace.ReceivedBatches)
+		assert.EqualValues(t, i+1, trace.SpanCount.Load())
+	}
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	require.Len(t, sink.AllTraces(), 3)
+	for i, id := range traceIds {
+		trace := findTrace(t, sink.AllTraces(), id)
+		assert.Equal(t, i+1, trace.SpanCount())
+	}
+	assert.Empty(t, tsp.storage.pending)
+}
+
+func TestStorageRestoresPendingTraces(t *testing.T) {
+	storageDir := t.TempDir()
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	tsp := newStorageTestProcessor(t, ext.ID(), 100, new(consumertest.TracesSink))
+	require.NoError(t, tsp.Start(context.Background(), host))
+
+	traceIds, batches := generateIdsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// Start a new processor on the same storage, as after a restart.
+	ext = storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host = storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	sink := new(consumertest.TracesSink)
+	tsp = newStorageTestProcessor(t, ext.ID(), 100, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	for i, id := range traceIds {
+		d, ok := tsp.idToTrace.Load(id)
+		require.True(t, ok, "trace %d was not restored", i)
+		trace := d.(*sampling.TraceData)
+		assert.EqualValues(t, i+1, trace.SpanCount.Load())
+		assert.Equal(t, sampling.Pending, trace.Decisions[0])
+	}
+	assert.EqualValues(t, 3, tsp.numTracesOnMap.Load())
+
+	// Spans arriving after the restart are added to the restored traces.
+	late := simpleTracesWithID(traceIds[0])
+	late.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetSpanID(uInt64ToSpanID(100))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), late))
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	require.Len(t, sink.AllTraces(), 3)
+	assert.Equal(t, 2, findTrace(t, sink.AllTraces(), traceIds[0]).SpanCount())
+	assert.Equal(t, 2, findTrace(t, sink.AllTraces(), traceIds[1]).SpanCount())
+	assert.Equal(t, 3, findTrace(t, sink.AllTraces(), traceIds[2]).SpanCount())
+}
+
+func TestStorageDroppedTracesAreRemoved(t *testing.T) {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID(), ext)
+	tsp := newStorageTestProcessor(t, ext.ID(), 2, new(consumertest.TracesSink))
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceIds, batches := generateIdsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	_, ok := tsp.idToTrace.Load(traceIds[0])
+	assert.False(t, ok)
+	assert.NotContains(t, tsp.storage.pending, traceIds[0])
+	value, err := tsp.storage.client.Get(context.Background(), batchKey(traceIds[0], 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Len(t, tsp.storage.pending, 2)
+}
+
+func TestStorageExtensionErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		storageID config.ComponentID
+		host      *storagetest.StorageHost
+	}{
+		{
+			name:      "missing",
+			storageID: storagetest.NewStorageID("missing"),
+			host:      storagetest.NewStorageHost().WithInMemoryStorageExtension("test"),
+		},
+		{
+			name:      "non_storage",
+			storageID: storagetest.NewNonStorageID("test"),
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsp := newStorageTestProcessor(t, tt.storageID, 100, new(consumertest.TracesSink))
+			assert.Error(t, tsp.Start(context.Background(), tt.host))
+		})
+	}
+}
+
+func TestStorageInvalidPendingTraces(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+	require.NoError(t, client.Set(context.Background(), pendingTracesKey, []byte{1, 2, 3}))
+
+	s := newTraceStorage(client, zap.NewNop())
+	_, err := s.restore(context.Background())
+	assert.ErrorIs(t, err, errInvalidPendingTraces)
+}
+
+func TestStorageFlushOnlyWhenChanged(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, config.NewComponentID(typeStr), "")
+	s := newTraceStorage(client, zap.NewNop())
+
+	require.NoError(t, s.flush(context.Background()))
+	value, err := client.Get(context.Background(), pendingTracesKey)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	traceIds, batches := generateIdsAndBatches(1)
+	arrival := time.Unix(10, 0)
+	s.track(traceIds[0], arrival)
+	require.True(t, s.appendBatch(context.Background(), traceIds[0], batches[0]))
+	require.NoError(t, s.flush(context.Background()))
+	value, err = client.Get(context.Background(), pendingTracesKey)
+	require.NoError(t, err)
+	assert.Len(t, value, pendingTraceSize)
+
+	restored, err := newTraceStorage(client, zap.NewNop()).restore(context.Background())
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, storedTrace{id: traceIds[0], arrivalTime: arrival, numBatches: 1, spanCount: 1}, restored[0])
+}
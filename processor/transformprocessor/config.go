@@ -18,9 +18,6 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/multierr"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
@@ -39,36 +36,13 @@ var _ config.Processor = (*Config)(nil)
 func (c *Config) Validate() error {
 	var errors error
 
-	tqlp := tql.NewParser(
-		traces.Functions(),
-		tqltraces.ParsePath,
-		tqltraces.ParseEnum,
-		tql.NoOpLogger{},
-	)
-	_, err := tqlp.ParseQueries(c.Traces.Queries)
-	if err != nil {
+	if _, err := traces.ParseQueries(c.Traces, traces.Functions(), tql.NoOpLogger{}); err != nil {
 		errors = multierr.Append(errors, err)
 	}
-
-	tqlp = tql.NewParser(
-		metrics.Functions(),
-		tqlmetrics.ParsePath,
-		tqlmetrics.ParseEnum,
-		tql.NoOpLogger{},
-	)
-	_, err = tqlp.ParseQueries(c.Metrics.Queries)
-	if err != nil {
+	if _, err := metrics.ParseQueries(c.Metrics, metrics.Functions(), tql.NoOpLogger{}); err != nil {
 		errors = multierr.Append(errors, err)
 	}
-
-	tqlp = tql.NewParser(
-		logs.Functions(),
-		tqllogs.ParsePath,
-		tqllogs.ParseEnum,
-		tql.NoOpLogger{},
-	)
-	_, err = tqlp.ParseQueries(c.Logs.Queries)
-	if err != nil {
+	if _, err := logs.ParseQueries(c.Logs, logs.Functions(), tql.NoOpLogger{}); err != nil {
 		errors = multierr.Append(errors, err)
 	}
 	return errors
@@ -39,16 +39,20 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 				Config: tqlconfig.Config{
-					Traces: tqlconfig.SignalConfig{
-						Queries: []string{
-							`set(name, "bear") where attributes["http.path"] == "/animal"`,
-							`keep_keys(attributes, "http.method", "http.path")`,
+					Traces: tqlconfig.TracesConfig{
+						SignalConfig: tqlconfig.SignalConfig{
+							Queries: []string{
+								`set(name, "bear") where attributes["http.path"] == "/animal"`,
+								`keep_keys(attributes, "http.method", "http.path")`,
+							},
 						},
 					},
-					Metrics: tqlconfig.SignalConfig{
-						Queries: []string{
-							`set(metric.name, "bear") where attributes["http.path"] == "/animal"`,
-							`keep_keys(attributes, "http.method", "http.path")`,
+					Metrics: tqlconfig.MetricsConfig{
+						SignalConfig: tqlconfig.SignalConfig{
+							Queries: []string{
+								`set(metric.name, "bear") where attributes["http.path"] == "/animal"`,
+								`keep_keys(attributes, "http.method", "http.path")`,
+							},
 						},
 					},
 					Logs: tqlconfig.SignalConfig{
@@ -60,6 +64,40 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "contexts"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Config: tqlconfig.Config{
+					Traces: tqlconfig.TracesConfig{
+						SignalConfig: tqlconfig.SignalConfig{
+							ResourceQueries: []string{`set(attributes["env"], "prod")`},
+							ScopeQueries:    []string{`set(version, "v2") where name == "io.opentelemetry"`},
+							Queries:         []string{`set(name, "bear") where attributes["http.path"] == "/animal"`},
+						},
+						SpanEventQueries: []string{`delete_key(attributes, "exception.stacktrace")`},
+					},
+					Metrics: tqlconfig.MetricsConfig{
+						SignalConfig: tqlconfig.SignalConfig{
+							Queries: []string{},
+						},
+						MetricQueries: []string{`set(description, "bear") where name == "animals"`},
+					},
+					Logs: tqlconfig.SignalConfig{
+						Queries:         []string{},
+						ResourceQueries: []string{`set(attributes["env"], "prod")`},
+					},
+				},
+			},
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "bad_syntax_resource"),
+			errorMessage: "1:31: unexpected token \"where\" (expected \")\")",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_path_span_event"),
+			errorMessage: "invalid argument at position 0 invalid path expression, unrecognized field status",
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "bad_syntax_trace"),
 			errorMessage: "1:18: unexpected token \"where\" (expected \")\")",
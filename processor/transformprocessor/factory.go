@@ -53,11 +53,15 @@ func createDefaultConfig() config.Processor {
 			Logs: tqlconfig.SignalConfig{
 				Queries: []string{},
 			},
-			Traces: tqlconfig.SignalConfig{
-				Queries: []string{},
+			Traces: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{},
+				},
 			},
-			Metrics: tqlconfig.SignalConfig{
-				Queries: []string{},
+			Metrics: tqlconfig.MetricsConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{},
+				},
 			},
 		},
 	}
@@ -71,7 +75,7 @@ func createLogsProcessor(
 ) (component.LogsProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := logs.NewProcessor(oCfg.Logs.Queries, logs.Functions(), set)
+	proc, err := logs.NewProcessor(oCfg.Logs, logs.Functions(), set)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -92,7 +96,7 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := traces.NewProcessor(oCfg.Traces.Queries, traces.Functions(), set)
+	proc, err := traces.NewProcessor(oCfg.Traces, traces.Functions(), set)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -113,7 +117,7 @@ func createMetricsProcessor(
 ) (component.MetricsProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	proc, err := metrics.NewProcessor(oCfg.Metrics.Queries, metrics.Functions(), set)
+	proc, err := metrics.NewProcessor(oCfg.Metrics, metrics.Functions(), set)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -41,11 +41,15 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, cfg, &Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 		Config: tqlconfig.Config{
-			Traces: tqlconfig.SignalConfig{
-				Queries: []string{},
+			Traces: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{},
+				},
 			},
-			Metrics: tqlconfig.SignalConfig{
-				Queries: []string{},
+			Metrics: tqlconfig.MetricsConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{},
+				},
 			},
 			Logs: tqlconfig.SignalConfig{
 				Queries: []string{},
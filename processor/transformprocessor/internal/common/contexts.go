@@ -0,0 +1,54 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// ParseResourceQueries parses queries executed in the Resource Context, they
+// are the same for all the signals.
+func ParseResourceQueries(statements []string, logger tql.Logger) ([]tql.Query, error) {
+	tqlp := tql.NewParser(
+		Functions(),
+		tqlresource.ParsePath,
+		tqlresource.ParseEnum,
+		logger,
+	)
+	return tqlp.ParseQueries(statements)
+}
+
+// ParseScopeQueries parses queries executed in the Instrumentation Scope
+// Context, they are the same for all the signals.
+func ParseScopeQueries(statements []string, logger tql.Logger) ([]tql.Query, error) {
+	tqlp := tql.NewParser(
+		Functions(),
+		tqlscope.ParsePath,
+		tqlscope.ParseEnum,
+		logger,
+	)
+	return tqlp.ParseQueries(statements)
+}
+
+// ExecuteQueries calls the function of each query whose condition is met.
+func ExecuteQueries(queries []tql.Query, ctx tql.TransformContext) {
+	for _, statement := range queries {
+		if statement.Condition(ctx) {
+			statement.Function(ctx)
+		}
+	}
+}
@@ -24,7 +24,20 @@ var registry = map[string]interface{}{
 	"SpanID":               tqlotel.SpanID,
 	"IsMatch":              tqlcommon.IsMatch,
 	"Concat":               tqlcommon.Concat,
+	"Int":                  tqlcommon.Int,
+	"Double":               tqlcommon.Double,
+	"String":               tqlcommon.String,
+	"ConvertCase":          tqlcommon.ConvertCase,
+	"Substring":            tqlcommon.Substring,
+	"SHA1":                 tqlcommon.SHA1,
+	"SHA256":               tqlcommon.SHA256,
+	"FNV":                  tqlcommon.FNV,
+	"Time":                 tqlcommon.Time,
+	"UnixNano":             tqlcommon.UnixNano,
 	"Split":                tqlotel.Split,
+	"ParseJSON":            tqlcommon.ParseJSON,
+	"ParseKeyValue":        tqlcommon.ParseKeyValue,
+	"ExtractPatterns":      tqlcommon.ExtractPatterns,
 	"keep_keys":            tqlotel.KeepKeys,
 	"set":                  tqlcommon.Set,
 	"truncate_all":         tqlotel.TruncateAll,
@@ -35,6 +48,7 @@ var registry = map[string]interface{}{
 	"replace_all_patterns": tqlotel.ReplaceAllPatterns,
 	"delete_key":           tqlotel.DeleteKey,
 	"delete_matching_keys": tqlotel.DeleteMatchingKeys,
+	"merge_maps":           tqlotel.MergeMaps,
 }
 
 func Functions() map[string]interface{} {
@@ -22,45 +22,67 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 type Processor struct {
-	queries []tql.Query
-	logger  *zap.Logger
+	resourceQueries []tql.Query
+	scopeQueries    []tql.Query
+	queries         []tql.Query
+	logger          *zap.Logger
 }
 
-func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+func NewProcessor(config tqlconfig.SignalConfig, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+	queries, err := ParseQueries(config, functions, common.NewTQLLogger(settings.Logger))
+	if err != nil {
+		return nil, err
+	}
+	queries.logger = settings.Logger
+	return queries, nil
+}
+
+// ParseQueries parses the queries of every context of the configuration.
+func ParseQueries(config tqlconfig.SignalConfig, functions map[string]interface{}, logger tql.Logger) (*Processor, error) {
+	resourceQueries, err := common.ParseResourceQueries(config.ResourceQueries, logger)
+	if err != nil {
+		return nil, err
+	}
+	scopeQueries, err := common.ParseScopeQueries(config.ScopeQueries, logger)
+	if err != nil {
+		return nil, err
+	}
 	tqlp := tql.NewParser(
 		functions,
 		tqllogs.ParsePath,
 		tqllogs.ParseEnum,
-		common.NewTQLLogger(settings.Logger),
+		logger,
 	)
-	queries, err := tqlp.ParseQueries(statements)
+	queries, err := tqlp.ParseQueries(config.Queries)
 	if err != nil {
 		return nil, err
 	}
 	return &Processor{
-		queries: queries,
-		logger:  settings.Logger,
+		resourceQueries: resourceQueries,
+		scopeQueries:    scopeQueries,
+		queries:         queries,
 	}, nil
 }
 
 func (p *Processor) ProcessLogs(_ context.Context, td plog.Logs) (plog.Logs, error) {
 	for i := 0; i < td.ResourceLogs().Len(); i++ {
 		rlogs := td.ResourceLogs().At(i)
+		common.ExecuteQueries(p.resourceQueries, tqlresource.NewTransformContext(rlogs.Resource()))
 		for j := 0; j < rlogs.ScopeLogs().Len(); j++ {
 			slogs := rlogs.ScopeLogs().At(j)
+			common.ExecuteQueries(p.scopeQueries, tqlscope.NewTransformContext(slogs.Scope(), rlogs.Resource()))
 			logs := slogs.LogRecords()
 			for k := 0; k < logs.Len(); k++ {
 				ctx := tqllogs.NewTransformContext(logs.At(k), slogs.Scope(), rlogs.Resource())
-				for _, statement := range p.queries {
-					if statement.Condition(ctx) {
-						statement.Function(ctx)
-					}
-				}
+				common.ExecuteQueries(p.queries, ctx)
 			}
 		}
 	}
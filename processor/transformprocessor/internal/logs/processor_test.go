@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 )
 
 var (
@@ -178,12 +180,111 @@ func TestProcess(t *testing.T) {
 			query: `set(attributes["test"], Split(attributes["not_exist"], "|"))`,
 			want:  func(td plog.Logs) {},
 		},
+		{
+			query: `set(time_unix_nano, UnixNano(Time("2022-10-01 12:30:45", "2006-01-02 15:04:05"))) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2022, 10, 1, 12, 30, 45, 0, time.UTC)))
+			},
+		},
+		{
+			query: `set(attributes["http.url"], SHA256(attributes["http.url"])) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutString("http.url", "91234995d8917e46a4e0cd6ce5c38981e1ea5b09debe83616342c2097f27726f")
+			},
+		},
+		{
+			query: `set(attributes["test"], ConvertCase(Substring(attributes["http.method"], 0, 1), "upper"))`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutString("test", "G")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutString("test", "G")
+			},
+		},
+		{
+			query: `set(attributes["test"], ParseJSON("{\"user\": {\"id\": 1}}")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				user := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("test").PutEmptyMap("user")
+				user.PutInt("id", 1)
+			},
+		},
+		{
+			query: `merge_maps(attributes, ParseKeyValue("http.method=post http.status_code=200", "=", ""), "upsert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				attrs := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+				attrs.PutString("http.method", "post")
+				attrs.PutString("http.status_code", "200")
+			},
+		},
+		{
+			query: `merge_maps(attributes, ExtractPatterns(attributes["http.url"], "^(?P<scheme>\\w+)://(?P<host>[^/]+)"), "insert")`,
+			want: func(td plog.Logs) {
+				attrs0 := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+				attrs0.PutString("host", "localhost")
+				attrs0.PutString("scheme", "http")
+				attrs1 := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes()
+				attrs1.PutString("host", "localhost")
+				attrs1.PutString("scheme", "http")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]string{tt.query}, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tqlconfig.SignalConfig{Queries: []string{tt.query}}, Functions(), component.ProcessorCreateSettings{})
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessLogs(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructLogs()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestProcess_Contexts(t *testing.T) {
+	tests := []struct {
+		name   string
+		config tqlconfig.SignalConfig
+		want   func(td plog.Logs)
+	}{
+		{
+			name: "resource",
+			config: tqlconfig.SignalConfig{
+				ResourceQueries: []string{`set(attributes["host.name"], "remotehost") where attributes["host.name"] == "localhost"`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).Resource().Attributes().PutString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "scope",
+			config: tqlconfig.SignalConfig{
+				ScopeQueries: []string{`set(name, "scope") where resource.attributes["host.name"] == "localhost"`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).Scope().SetName("scope")
+			},
+		},
+		{
+			name: "scope before log records",
+			config: tqlconfig.SignalConfig{
+				ScopeQueries: []string{`set(name, "scope")`},
+				Queries:      []string{`set(attributes["scope"], instrumentation_scope.name) where body == "operationA"`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).Scope().SetName("scope")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutString("scope", "scope")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructLogs()
+			processor, err := NewProcessor(tt.config, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
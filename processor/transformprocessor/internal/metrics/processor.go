@@ -22,41 +22,82 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlmetrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 type Processor struct {
-	queries []tql.Query
-	logger  *zap.Logger
+	resourceQueries []tql.Query
+	scopeQueries    []tql.Query
+	metricQueries   []tql.Query
+	queries         []tql.Query
+	logger          *zap.Logger
 }
 
-func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+func NewProcessor(config tqlconfig.MetricsConfig, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+	queries, err := ParseQueries(config, functions, common.NewTQLLogger(settings.Logger))
+	if err != nil {
+		return nil, err
+	}
+	queries.logger = settings.Logger
+	return queries, nil
+}
+
+// ParseQueries parses the queries of every context of the configuration. The
+// metric queries only have access to the functions common to all signals.
+func ParseQueries(config tqlconfig.MetricsConfig, functions map[string]interface{}, logger tql.Logger) (*Processor, error) {
+	resourceQueries, err := common.ParseResourceQueries(config.ResourceQueries, logger)
+	if err != nil {
+		return nil, err
+	}
+	scopeQueries, err := common.ParseScopeQueries(config.ScopeQueries, logger)
+	if err != nil {
+		return nil, err
+	}
 	tqlp := tql.NewParser(
+		common.Functions(),
+		tqlmetric.ParsePath,
+		tqlmetric.ParseEnum,
+		logger,
+	)
+	metricQueries, err := tqlp.ParseQueries(config.MetricQueries)
+	if err != nil {
+		return nil, err
+	}
+	tqlp = tql.NewParser(
 		functions,
 		tqlmetrics.ParsePath,
 		tqlmetrics.ParseEnum,
-		common.NewTQLLogger(settings.Logger),
+		logger,
 	)
-	queries, err := tqlp.ParseQueries(statements)
+	queries, err := tqlp.ParseQueries(config.Queries)
 	if err != nil {
 		return nil, err
 	}
 	return &Processor{
-		queries: queries,
-		logger:  settings.Logger,
+		resourceQueries: resourceQueries,
+		scopeQueries:    scopeQueries,
+		metricQueries:   metricQueries,
+		queries:         queries,
 	}, nil
 }
 
 func (p *Processor) ProcessMetrics(_ context.Context, td pmetric.Metrics) (pmetric.Metrics, error) {
 	for i := 0; i < td.ResourceMetrics().Len(); i++ {
 		rmetrics := td.ResourceMetrics().At(i)
+		common.ExecuteQueries(p.resourceQueries, tqlresource.NewTransformContext(rmetrics.Resource()))
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
+			common.ExecuteQueries(p.scopeQueries, tqlscope.NewTransformContext(smetrics.Scope(), rmetrics.Resource()))
 			metrics := smetrics.Metrics()
 			for k := 0; k < metrics.Len(); k++ {
 				metric := metrics.At(k)
+				common.ExecuteQueries(p.metricQueries, tqlmetric.NewTransformContext(metric, metrics, smetrics.Scope(), rmetrics.Resource()))
 				switch metric.DataType() {
 				case pmetric.MetricDataTypeSum:
 					p.handleNumberDataPoints(metric.Sum().DataPoints(), metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource())
@@ -104,9 +145,5 @@ func (p *Processor) handleSummaryDataPoints(dps pmetric.SummaryDataPointSlice, m
 }
 
 func (p *Processor) callFunctions(ctx tql.TransformContext) {
-	for _, statement := range p.queries {
-		if statement.Condition(ctx) {
-			statement.Function(ctx)
-		}
-	}
+	common.ExecuteQueries(p.queries, ctx)
 }
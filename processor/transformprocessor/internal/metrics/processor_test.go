@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 )
 
 var (
@@ -314,7 +316,78 @@ func TestProcess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.query[0], func(t *testing.T) {
 			td := constructMetrics()
-			processor, err := NewProcessor(tt.query, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tqlconfig.MetricsConfig{SignalConfig: tqlconfig.SignalConfig{Queries: tt.query}}, Functions(), component.ProcessorCreateSettings{})
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessMetrics(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructMetrics()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestProcess_Contexts(t *testing.T) {
+	tests := []struct {
+		name   string
+		config tqlconfig.MetricsConfig
+		want   func(td pmetric.Metrics)
+	}{
+		{
+			name: "resource",
+			config: tqlconfig.MetricsConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					ResourceQueries: []string{`set(attributes["host.name"], "remotehost") where attributes["host.name"] == "myhost"`},
+				},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).Resource().Attributes().PutString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "scope",
+			config: tqlconfig.MetricsConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					ScopeQueries: []string{`set(name, "scope") where resource.attributes["host.name"] == "myhost"`},
+				},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().SetName("scope")
+			},
+		},
+		{
+			name: "metric",
+			config: tqlconfig.MetricsConfig{
+				MetricQueries: []string{`set(unit, "ms") where name == "operationA"`},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetUnit("ms")
+			},
+		},
+		{
+			name: "metrics before data points",
+			config: tqlconfig.MetricsConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{`set(attributes["unit"], metric.unit) where metric.name == "operationA"`},
+				},
+				MetricQueries: []string{`set(unit, "ms") where name == "operationA"`},
+			},
+			want: func(td pmetric.Metrics) {
+				m := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+				m.SetUnit("ms")
+				m.Sum().DataPoints().At(0).Attributes().PutString("unit", "ms")
+				m.Sum().DataPoints().At(1).Attributes().PutString("unit", "ms")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructMetrics()
+			processor, err := NewProcessor(tt.config, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessMetrics(context.Background(), td)
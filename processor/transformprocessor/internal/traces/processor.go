@@ -21,45 +21,88 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqlspanevents"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/tqltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
 type Processor struct {
-	queries []tql.Query
-	logger  *zap.Logger
+	resourceQueries  []tql.Query
+	scopeQueries     []tql.Query
+	queries          []tql.Query
+	spanEventQueries []tql.Query
+	logger           *zap.Logger
 }
 
-func NewProcessor(statements []string, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+func NewProcessor(config tqlconfig.TracesConfig, functions map[string]interface{}, settings component.ProcessorCreateSettings) (*Processor, error) {
+	queries, err := ParseQueries(config, functions, common.NewTQLLogger(settings.Logger))
+	if err != nil {
+		return nil, err
+	}
+	queries.logger = settings.Logger
+	return queries, nil
+}
+
+// ParseQueries parses the queries of every context of the configuration.
+func ParseQueries(config tqlconfig.TracesConfig, functions map[string]interface{}, logger tql.Logger) (*Processor, error) {
+	resourceQueries, err := common.ParseResourceQueries(config.ResourceQueries, logger)
+	if err != nil {
+		return nil, err
+	}
+	scopeQueries, err := common.ParseScopeQueries(config.ScopeQueries, logger)
+	if err != nil {
+		return nil, err
+	}
 	tqlp := tql.NewParser(
 		functions,
 		tqltraces.ParsePath,
 		tqltraces.ParseEnum,
-		common.NewTQLLogger(settings.Logger),
+		logger,
+	)
+	queries, err := tqlp.ParseQueries(config.Queries)
+	if err != nil {
+		return nil, err
+	}
+	tqlp = tql.NewParser(
+		functions,
+		tqlspanevents.ParsePath,
+		tqlspanevents.ParseEnum,
+		logger,
 	)
-	queries, err := tqlp.ParseQueries(statements)
+	spanEventQueries, err := tqlp.ParseQueries(config.SpanEventQueries)
 	if err != nil {
 		return nil, err
 	}
 	return &Processor{
-		queries: queries,
-		logger:  settings.Logger,
+		resourceQueries:  resourceQueries,
+		scopeQueries:     scopeQueries,
+		queries:          queries,
+		spanEventQueries: spanEventQueries,
 	}, nil
 }
 
 func (p *Processor) ProcessTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	for i := 0; i < td.ResourceSpans().Len(); i++ {
 		rspans := td.ResourceSpans().At(i)
+		common.ExecuteQueries(p.resourceQueries, tqlresource.NewTransformContext(rspans.Resource()))
 		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
 			sspan := rspans.ScopeSpans().At(j)
+			common.ExecuteQueries(p.scopeQueries, tqlscope.NewTransformContext(sspan.Scope(), rspans.Resource()))
 			spans := sspan.Spans()
 			for k := 0; k < spans.Len(); k++ {
-				ctx := tqltraces.NewTransformContext(spans.At(k), sspan.Scope(), rspans.Resource())
-				for _, statement := range p.queries {
-					if statement.Condition(ctx) {
-						statement.Function(ctx)
-					}
+				span := spans.At(k)
+				ctx := tqltraces.NewTransformContext(span, sspan.Scope(), rspans.Resource())
+				common.ExecuteQueries(p.queries, ctx)
+				if len(p.spanEventQueries) == 0 {
+					continue
+				}
+				for l := 0; l < span.Events().Len(); l++ {
+					eventCtx := tqlspanevents.NewTransformContext(span.Events().At(l), span, sspan.Scope(), rspans.Resource())
+					common.ExecuteQueries(p.spanEventQueries, eventCtx)
 				}
 			}
 		}
@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tqlconfig"
 )
 
 var (
@@ -62,6 +64,26 @@ func TestProcess(t *testing.T) {
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutString("http.method", "get")
 			},
 		},
+		{
+			query: `keep_keys(attributes, ["http.method", "http.path"]) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Clear()
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutString("http.method", "get")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutString("http.path", "/health")
+			},
+		},
+		{
+			query: `set(attributes["test"], "pass") where not name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutString("test", "pass")
+			},
+		},
+		{
+			query: `set(attributes["test"], (end_time_unix_nano - start_time_unix_nano) / 1000) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutInt("test", int64(TestSpanEndTimestamp-TestSpanStartTimestamp)/1000)
+			},
+		},
 		{
 			query: `set(status.code, 1) where attributes["http.path"] == "/health"`,
 			want: func(td ptrace.Traces) {
@@ -223,7 +245,91 @@ func TestProcess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]string{tt.query}, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tqlconfig.TracesConfig{SignalConfig: tqlconfig.SignalConfig{Queries: []string{tt.query}}}, Functions(), component.ProcessorCreateSettings{})
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestProcess_Contexts(t *testing.T) {
+	tests := []struct {
+		name   string
+		config tqlconfig.TracesConfig
+		want   func(td ptrace.Traces)
+	}{
+		{
+			name: "resource",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					ResourceQueries: []string{`set(attributes["host.name"], "remotehost") where attributes["host.name"] == "localhost"`},
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).Resource().Attributes().PutString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "scope",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					ScopeQueries: []string{`set(version, "v2") where name == "scope" and resource.attributes["host.name"] == "localhost"`},
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Scope().SetVersion("v2")
+			},
+		},
+		{
+			name: "span event",
+			config: tqlconfig.TracesConfig{
+				SpanEventQueries: []string{`set(attributes["exception.message"], "redacted") where name == "exception" and span.name == "operationA"`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().PutString("exception.message", "redacted")
+			},
+		},
+		{
+			name: "resource before spans",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					ResourceQueries: []string{`set(attributes["env"], "test")`},
+					Queries:         []string{`set(attributes["env"], resource.attributes["env"])`},
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).Resource().Attributes().PutString("env", "test")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutString("env", "test")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutString("env", "test")
+			},
+		},
+		{
+			name: "span events after their span",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{
+					Queries: []string{`set(attributes["redact"], true) where name == "operationA"`},
+				},
+				SpanEventQueries: []string{`set(attributes["exception.message"], "redacted") where span.attributes["redact"] == true`},
+			},
+			want: func(td ptrace.Traces) {
+				span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+				span.Attributes().PutBool("redact", true)
+				span.Events().At(0).Attributes().PutString("exception.message", "redacted")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor(tt.config, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessTraces(context.Background(), td)
@@ -237,6 +343,38 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestNewProcessor_InvalidContextQueries(t *testing.T) {
+	tests := []struct {
+		name   string
+		config tqlconfig.TracesConfig
+	}{
+		{
+			name: "resource",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{ResourceQueries: []string{`set(name, "bear")`}},
+			},
+		},
+		{
+			name: "scope",
+			config: tqlconfig.TracesConfig{
+				SignalConfig: tqlconfig.SignalConfig{ScopeQueries: []string{`set(status.code, 1)`}},
+			},
+		},
+		{
+			name: "span event",
+			config: tqlconfig.TracesConfig{
+				SpanEventQueries: []string{`set(status.code, 1)`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProcessor(tt.config, Functions(), component.ProcessorCreateSettings{})
+			assert.Error(t, err)
+		})
+	}
+}
+
 func BenchmarkTwoSpans(b *testing.B) {
 	tests := []struct {
 		name    string
@@ -273,7 +411,7 @@ func BenchmarkTwoSpans(b *testing.B) {
 
 	for _, tt := range tests {
 		b.Run(tt.name, func(b *testing.B) {
-			processor, err := NewProcessor(tt.queries, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tqlconfig.TracesConfig{SignalConfig: tqlconfig.SignalConfig{Queries: tt.queries}}, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(b, err)
 			b.ResetTimer()
 			for n := 0; n < b.N; n++ {
@@ -315,7 +453,7 @@ func BenchmarkHundredSpans(b *testing.B) {
 	}
 	for _, tt := range tests {
 		b.Run(tt.name, func(b *testing.B) {
-			processor, err := NewProcessor(tt.queries, Functions(), component.ProcessorCreateSettings{})
+			processor, err := NewProcessor(tqlconfig.TracesConfig{SignalConfig: tqlconfig.SignalConfig{Queries: tt.queries}}, Functions(), component.ProcessorCreateSettings{})
 			assert.NoError(b, err)
 			b.ResetTimer()
 			for n := 0; n < b.N; n++ {
@@ -332,6 +470,7 @@ func constructTraces() ptrace.Traces {
 	rs0 := td.ResourceSpans().AppendEmpty()
 	rs0.Resource().Attributes().PutString("host.name", "localhost")
 	rs0ils0 := rs0.ScopeSpans().AppendEmpty()
+	rs0ils0.Scope().SetName("scope")
 	fillSpanOne(rs0ils0.Spans().AppendEmpty())
 	fillSpanTwo(rs0ils0.Spans().AppendEmpty())
 	return td
@@ -363,6 +502,9 @@ func fillSpanOne(span ptrace.Span) {
 	span.Attributes().PutString("http.path", "/health")
 	span.Attributes().PutString("http.url", "http://localhost/health")
 	span.Attributes().PutString("flags", "A|B|C")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutString("exception.message", "invalid token abc123")
 	status := span.Status()
 	status.SetCode(ptrace.StatusCodeError)
 	status.SetMessage("status-cancelled")
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r := newMetricsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), next)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return fmt.Sprintf("http://%s%s", cfg.Endpoint, writePath)
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	buf, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func post(t *testing.T, url string, encoding string, body []byte) int {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+var testWriteRequest = &prompb.WriteRequest{
+	Timeseries: []prompb.TimeSeries{
+		{
+			Labels: []prompb.Label{
+				{Name: "__name__", Value: "up"},
+				{Name: "instance", Value: "localhost:9100"},
+				{Name: "job", Value: "node"},
+			},
+			Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+		},
+	},
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	assert.Equal(t, http.StatusNoContent, post(t, url, "snappy", encodeWriteRequest(t, testWriteRequest)))
+	assert.Equal(t, http.StatusNoContent, post(t, url, "", encodeWriteRequest(t, testWriteRequest)))
+
+	require.Len(t, sink.AllMetrics(), 2)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.DataPointCount())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "up", metric.Name())
+	assert.Equal(t, 1.0, metric.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestReceiveMetadataOnly(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	req := &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "up", Type: prompb.MetricMetadata_GAUGE},
+		},
+	}
+	assert.Equal(t, http.StatusNoContent, post(t, url, "snappy", encodeWriteRequest(t, req)))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestReceiveInvalidRequests(t *testing.T) {
+	url := startReceiver(t, consumertest.NewNop())
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		status   int
+	}{
+		{
+			name:     "unsupported encoding",
+			encoding: "br",
+			body:     encodeWriteRequest(t, testWriteRequest),
+			status:   http.StatusUnsupportedMediaType,
+		},
+		{
+			name:     "not compressed",
+			encoding: "snappy",
+			body:     []byte("not a write request"),
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "not a write request",
+			encoding: "snappy",
+			body:     snappy.Encode(nil, []byte("not a write request")),
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "series without name",
+			encoding: "snappy",
+			body: encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{
+					Labels:  []prompb.Label{{Name: "job", Value: "node"}},
+					Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+				}},
+			}),
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, post(t, url, tt.encoding, tt.body))
+		})
+	}
+}
+
+func TestReceiveMethodNotAllowed(t *testing.T) {
+	url := startReceiver(t, consumertest.NewNop())
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestReceiveConsumerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "retryable",
+			err:    errors.New("temporary failure"),
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "permanent",
+			err:    consumererror.NewPermanent(errors.New("invalid data")),
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tt.err))
+			assert.Equal(t, tt.status, post(t, url, "snappy", encodeWriteRequest(t, testWriteRequest)))
+		})
+	}
+}
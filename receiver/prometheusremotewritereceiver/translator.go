@@ -0,0 +1,489 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	targetInfoName = "target_info"
+
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+	totalSuffix  = "_total"
+)
+
+var errMissingName = errors.New("time series without a metric name")
+
+type familyType int
+
+const (
+	gaugeFamily familyType = iota
+	counterFamily
+	histogramFamily
+	summaryFamily
+)
+
+// translator converts the series of a single write request to OTLP metrics.
+type translator struct {
+	metadata map[string]prompb.MetricMetadata
+	// inferred holds the histograms and summaries recognized from the labels of
+	// their series, for families without metadata.
+	inferred  map[string]familyType
+	targets   map[targetKey][]prompb.Label
+	resources map[targetKey]*resourceBuilder
+
+	md pmetric.Metrics
+}
+
+// targetKey identifies the target a series was scraped from.
+type targetKey struct {
+	job      string
+	instance string
+}
+
+type resourceBuilder struct {
+	metrics  pmetric.MetricSlice
+	families map[string]*family
+	order    []*family
+}
+
+// family accumulates the series of one metric family. Histogram and summary
+// points are assembled from several series, they are only written to the
+// metric once all the series were seen.
+type family struct {
+	typ    familyType
+	metric pmetric.Metric
+	points map[string]*compoundPoint
+	order  []*compoundPoint
+}
+
+// compoundPoint is a histogram or summary point built from several series.
+type compoundPoint struct {
+	labels    []prompb.Label
+	timestamp int64
+	bounds    map[float64]float64
+	sum       float64
+	hasSum    bool
+	count     float64
+	hasCount  bool
+	stale     bool
+}
+
+// toMetrics converts a remote write request to OTLP metrics. Series are
+// grouped by their job and instance labels into resources, whose attributes
+// are completed with the labels of the matching target_info series.
+func toMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	t := &translator{
+		metadata:  make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		inferred:  make(map[string]familyType),
+		targets:   make(map[targetKey][]prompb.Label),
+		resources: make(map[targetKey]*resourceBuilder),
+		md:        pmetric.NewMetrics(),
+	}
+	for _, m := range req.Metadata {
+		t.metadata[m.MetricFamilyName] = m
+	}
+
+	for _, ts := range req.Timeseries {
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		switch {
+		case name == "":
+			return pmetric.Metrics{}, errMissingName
+		case name == targetInfoName:
+			t.targets[targetKeyOf(ts.Labels)] = ts.Labels
+		case strings.HasSuffix(name, bucketSuffix) && hasLabel(ts.Labels, model.BucketLabel):
+			t.inferred[strings.TrimSuffix(name, bucketSuffix)] = histogramFamily
+		case hasLabel(ts.Labels, model.QuantileLabel):
+			t.inferred[name] = summaryFamily
+		}
+	}
+
+	for _, ts := range req.Timeseries {
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if name == targetInfoName {
+			continue
+		}
+		if err := t.addSeries(name, ts); err != nil {
+			return pmetric.Metrics{}, err
+		}
+	}
+
+	for _, rb := range t.resources {
+		for _, f := range rb.order {
+			f.finish()
+		}
+	}
+	return t.md, nil
+}
+
+func (t *translator) addSeries(name string, ts prompb.TimeSeries) error {
+	familyName, typ, suffix := t.familyOf(name, ts.Labels)
+	f := t.resource(targetKeyOf(ts.Labels)).family(familyName, typ, t.metadata)
+
+	switch typ {
+	case histogramFamily, summaryFamily:
+		for _, s := range ts.Samples {
+			if err := f.addCompoundSample(ts.Labels, suffix, s); err != nil {
+				return fmt.Errorf("series %s: %w", name, err)
+			}
+		}
+	default:
+		var dps pmetric.NumberDataPointSlice
+		if typ == counterFamily {
+			dps = f.metric.Sum().DataPoints()
+		} else {
+			dps = f.metric.Gauge().DataPoints()
+		}
+		for _, s := range ts.Samples {
+			dp := dps.AppendEmpty()
+			putAttributes(dp.Attributes(), ts.Labels)
+			dp.SetTimestamp(toTimestamp(s.Timestamp))
+			if value.IsStaleNaN(s.Value) {
+				dp.SetFlags(pmetric.DefaultMetricDataPointFlags.WithNoRecordedValue(true))
+				continue
+			}
+			dp.SetDoubleVal(s.Value)
+		}
+	}
+	return nil
+}
+
+// familyOf returns the family a series belongs to, its type and the suffix of
+// the series name within a histogram or summary family. Metadata takes
+// precedence over the types inferred from the labels, series of unknown
+// families are gauges, or counters when their name ends with _total.
+func (t *translator) familyOf(name string, labels []prompb.Label) (string, familyType, string) {
+	if m, ok := t.metadata[name]; ok {
+		switch m.Type {
+		case prompb.MetricMetadata_COUNTER:
+			return name, counterFamily, ""
+		case prompb.MetricMetadata_SUMMARY:
+			if hasLabel(labels, model.QuantileLabel) {
+				return name, summaryFamily, ""
+			}
+		}
+		return name, gaugeFamily, ""
+	}
+
+	if t.inferred[name] == summaryFamily && hasLabel(labels, model.QuantileLabel) {
+		return name, summaryFamily, ""
+	}
+	for _, suffix := range []string{bucketSuffix, sumSuffix, countSuffix} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		base := strings.TrimSuffix(name, suffix)
+		typ, ok := t.inferred[base]
+		if m, found := t.metadata[base]; found {
+			switch m.Type {
+			case prompb.MetricMetadata_HISTOGRAM:
+				typ, ok = histogramFamily, true
+			case prompb.MetricMetadata_SUMMARY:
+				typ, ok = summaryFamily, true
+			default:
+				ok = false
+			}
+		}
+		if !ok || (suffix == bucketSuffix && (typ != histogramFamily || !hasLabel(labels, model.BucketLabel))) {
+			continue
+		}
+		return base, typ, suffix
+	}
+
+	if strings.HasSuffix(name, totalSuffix) {
+		if m, ok := t.metadata[strings.TrimSuffix(name, totalSuffix)]; ok && m.Type != prompb.MetricMetadata_COUNTER {
+			return name, gaugeFamily, ""
+		}
+		return name, counterFamily, ""
+	}
+	return name, gaugeFamily, ""
+}
+
+func (t *translator) resource(key targetKey) *resourceBuilder {
+	if rb, ok := t.resources[key]; ok {
+		return rb
+	}
+
+	rm := t.md.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	if key.job != "" {
+		if namespace, name, found := strings.Cut(key.job, "/"); found {
+			attrs.PutString(conventions.AttributeServiceNamespace, namespace)
+			attrs.PutString(conventions.AttributeServiceName, name)
+		} else {
+			attrs.PutString(conventions.AttributeServiceName, key.job)
+		}
+	}
+	if key.instance != "" {
+		attrs.PutString(conventions.AttributeServiceInstanceID, key.instance)
+	}
+	putAttributes(attrs, t.targets[key])
+
+	rb := &resourceBuilder{
+		metrics:  rm.ScopeMetrics().AppendEmpty().Metrics(),
+		families: make(map[string]*family),
+	}
+	t.resources[key] = rb
+	return rb
+}
+
+func (rb *resourceBuilder) family(name string, typ familyType, metadata map[string]prompb.MetricMetadata) *family {
+	if f, ok := rb.families[name]; ok && f.typ == typ {
+		return f
+	}
+
+	metric := rb.metrics.AppendEmpty()
+	metric.SetName(name)
+	if m, ok := metadata[name]; ok {
+		metric.SetDescription(m.Help)
+		metric.SetUnit(m.Unit)
+	} else if m, ok = metadata[strings.TrimSuffix(name, totalSuffix)]; ok && typ == counterFamily {
+		metric.SetDescription(m.Help)
+		metric.SetUnit(m.Unit)
+	}
+	switch typ {
+	case counterFamily:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	case histogramFamily:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	case summaryFamily:
+		metric.SetEmptySummary()
+	default:
+		metric.SetEmptyGauge()
+	}
+
+	f := &family{typ: typ, metric: metric, points: make(map[string]*compoundPoint)}
+	rb.families[name] = f
+	rb.order = append(rb.order, f)
+	return f
+}
+
+// addCompoundSample adds the sample of a bucket, quantile, sum or count
+// series to the histogram or summary point it is part of.
+func (f *family) addCompoundSample(labels []prompb.Label, suffix string, s prompb.Sample) error {
+	boundLabel := model.BucketLabel
+	if f.typ == summaryFamily {
+		boundLabel = model.QuantileLabel
+	}
+
+	var bound float64
+	if suffix == bucketSuffix || (f.typ == summaryFamily && suffix == "") {
+		var err error
+		if bound, err = strconv.ParseFloat(labelValue(labels, boundLabel), 64); err != nil {
+			return fmt.Errorf("invalid %s label: %w", boundLabel, err)
+		}
+	}
+
+	key := pointKey(labels, boundLabel, s.Timestamp)
+	p, ok := f.points[key]
+	if !ok {
+		p = &compoundPoint{
+			labels:    labels,
+			timestamp: s.Timestamp,
+			bounds:    make(map[float64]float64),
+		}
+		f.points[key] = p
+		f.order = append(f.order, p)
+	}
+
+	if value.IsStaleNaN(s.Value) {
+		p.stale = true
+		return nil
+	}
+	switch suffix {
+	case sumSuffix:
+		p.sum, p.hasSum = s.Value, true
+	case countSuffix:
+		p.count, p.hasCount = s.Value, true
+	default:
+		p.bounds[bound] = s.Value
+	}
+	return nil
+}
+
+// finish writes the histogram and summary points of the family.
+func (f *family) finish() {
+	for _, p := range f.order {
+		switch f.typ {
+		case histogramFamily:
+			p.toHistogramPoint(f.metric.Histogram().DataPoints().AppendEmpty())
+		case summaryFamily:
+			p.toSummaryPoint(f.metric.Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+func (p *compoundPoint) toHistogramPoint(dp pmetric.HistogramDataPoint) {
+	putAttributes(dp.Attributes(), p.labels, model.BucketLabel)
+	dp.SetTimestamp(toTimestamp(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultMetricDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	les := make([]float64, 0, len(p.bounds))
+	for le := range p.bounds {
+		les = append(les, le)
+	}
+	sort.Float64s(les)
+
+	// Buckets are cumulative in Prometheus, the +Inf bucket holds the total
+	// count and becomes the overflow bucket.
+	bounds := make([]float64, 0, len(les))
+	counts := make([]uint64, 0, len(les)+1)
+	var previous float64
+	for _, le := range les {
+		if math.IsInf(le, 1) {
+			continue
+		}
+		bounds = append(bounds, le)
+		counts = append(counts, bucketCount(p.bounds[le], previous))
+		previous = math.Max(previous, p.bounds[le])
+	}
+
+	count := previous
+	if inf, ok := p.bounds[math.Inf(1)]; ok {
+		count = inf
+	}
+	if p.hasCount {
+		count = p.count
+	}
+	counts = append(counts, bucketCount(count, previous))
+
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+}
+
+func (p *compoundPoint) toSummaryPoint(dp pmetric.SummaryDataPoint) {
+	putAttributes(dp.Attributes(), p.labels, model.QuantileLabel)
+	dp.SetTimestamp(toTimestamp(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultMetricDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	quantiles := make([]float64, 0, len(p.bounds))
+	for q := range p.bounds {
+		quantiles = append(quantiles, q)
+	}
+	sort.Float64s(quantiles)
+	for _, q := range quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(p.bounds[q])
+	}
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+}
+
+// bucketCount returns the count of a bucket from its cumulative count and the
+// cumulative count of the previous bucket.
+func bucketCount(cumulative, previous float64) uint64 {
+	if cumulative <= previous {
+		return 0
+	}
+	return uint64(cumulative - previous)
+}
+
+// pointKey identifies the point a series of a histogram or summary belongs to,
+// from the labels other than the name and the bound, and the timestamp.
+func pointKey(labels []prompb.Label, boundLabel string, timestamp int64) string {
+	sorted := make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		if l.Name != model.MetricNameLabel && l.Name != boundLabel {
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	for _, l := range sorted {
+		b.WriteString(l.Name)
+		b.WriteByte(model.SeparatorByte)
+		b.WriteString(l.Value)
+		b.WriteByte(model.SeparatorByte)
+	}
+	b.WriteString(strconv.FormatInt(timestamp, 10))
+	return b.String()
+}
+
+// putAttributes copies the labels to the attributes, except for the name, job
+// and instance labels and the given ones.
+func putAttributes(attrs pcommon.Map, labels []prompb.Label, skip ...string) {
+LABELS:
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+			continue
+		}
+		for _, s := range skip {
+			if l.Name == s {
+				continue LABELS
+			}
+		}
+		attrs.PutString(l.Name, l.Value)
+	}
+}
+
+func targetKeyOf(labels []prompb.Label) targetKey {
+	return targetKey{
+		job:      labelValue(labels, model.JobLabel),
+		instance: labelValue(labels, model.InstanceLabel),
+	}
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toTimestamp converts a timestamp in milliseconds to an OTLP timestamp.
+func toTimestamp(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * int64(1e6))
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// series creates a series with a single sample from alternating label names
+// and values.
+func series(v float64, ts int64, labels ...string) prompb.TimeSeries {
+	s := prompb.TimeSeries{Samples: []prompb.Sample{{Value: v, Timestamp: ts}}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.Labels = append(s.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return s
+}
+
+// metricsByName returns the metrics of the only resource of md.
+func metricsByName(t *testing.T, md pmetric.Metrics) map[string]pmetric.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	byName := make(map[string]pmetric.Metric, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		byName[metrics.At(i).Name()] = metrics.At(i)
+	}
+	return byName
+}
+
+func TestToMetricsGaugesAndCounters(t *testing.T) {
+	md, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, 1000, "__name__", "up", "job", "node", "instance", "host:9100"),
+			series(42, 1000, "__name__", "http_requests_total", "job", "node", "instance", "host:9100", "code", "200"),
+			series(7, 1000, "__name__", "process_open_fds", "job", "node", "instance", "host:9100"),
+			series(3, 1000, "__name__", "temperature_total", "job", "node", "instance", "host:9100"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "process_open_fds", Type: prompb.MetricMetadata_COUNTER, Help: "Open file descriptors.", Unit: "fds"},
+			{MetricFamilyName: "temperature", Type: prompb.MetricMetadata_GAUGE},
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 4)
+
+	up := metrics["up"]
+	require.Equal(t, pmetric.MetricDataTypeGauge, up.DataType())
+	dp := up.Gauge().DataPoints().At(0)
+	assert.Equal(t, 1.0, dp.DoubleVal())
+	assert.Equal(t, pcommon.Timestamp(1000*1e6), dp.Timestamp())
+	assert.Equal(t, 0, dp.Attributes().Len())
+
+	requests := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricDataTypeSum, requests.DataType())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	dp = requests.Sum().DataPoints().At(0)
+	assert.Equal(t, 42.0, dp.DoubleVal())
+	assert.Equal(t, map[string]interface{}{"code": "200"}, dp.Attributes().AsRaw())
+
+	fds := metrics["process_open_fds"]
+	require.Equal(t, pmetric.MetricDataTypeSum, fds.DataType())
+	assert.Equal(t, "Open file descriptors.", fds.Description())
+	assert.Equal(t, "fds", fds.Unit())
+
+	// metadata takes precedence over the _total suffix
+	assert.Equal(t, pmetric.MetricDataTypeGauge, metrics["temperature_total"].DataType())
+}
+
+func TestToMetricsResources(t *testing.T) {
+	md, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, 1000, "__name__", "up", "job", "node", "instance", "host-a:9100"),
+			series(1, 1000, "__name__", "up", "job", "shop/checkout", "instance", "host-b:8080"),
+			series(1, 1000, "__name__", "target_info", "job", "shop/checkout", "instance", "host-b:8080", "deployment_environment", "production"),
+			series(1, 1000, "__name__", "up"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, md.ResourceMetrics().Len())
+
+	var resources []map[string]interface{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resources = append(resources, rm.Resource().Attributes().AsRaw())
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 1, metrics.Len())
+		assert.Equal(t, "up", metrics.At(0).Name())
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"service.name":        "node",
+			"service.instance.id": "host-a:9100",
+		},
+		{
+			"service.namespace":      "shop",
+			"service.name":           "checkout",
+			"service.instance.id":    "host-b:8080",
+			"deployment_environment": "production",
+		},
+		{},
+	}, resources)
+}
+
+func TestToMetricsHistogram(t *testing.T) {
+	histogramSeries := []prompb.TimeSeries{
+		series(2, 1000, "__name__", "request_duration_seconds_bucket", "job", "api", "le", "0.1", "path", "/"),
+		series(5, 1000, "__name__", "request_duration_seconds_bucket", "job", "api", "le", "1", "path", "/"),
+		series(6, 1000, "__name__", "request_duration_seconds_bucket", "job", "api", "le", "+Inf", "path", "/"),
+		series(3.5, 1000, "__name__", "request_duration_seconds_sum", "job", "api", "path", "/"),
+		series(6, 1000, "__name__", "request_duration_seconds_count", "job", "api", "path", "/"),
+		series(1, 1000, "__name__", "request_duration_seconds_bucket", "job", "api", "le", "+Inf", "path", "/health"),
+		series(1, 1000, "__name__", "request_duration_seconds_count", "job", "api", "path", "/health"),
+	}
+
+	tests := []struct {
+		name     string
+		metadata []prompb.MetricMetadata
+	}{
+		{
+			name: "inferred",
+		},
+		{
+			name: "metadata",
+			metadata: []prompb.MetricMetadata{
+				{MetricFamilyName: "request_duration_seconds", Type: prompb.MetricMetadata_HISTOGRAM, Help: "Request duration.", Unit: "seconds"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := toMetrics(&prompb.WriteRequest{Timeseries: histogramSeries, Metadata: tt.metadata})
+			require.NoError(t, err)
+			metrics := metricsByName(t, md)
+			require.Len(t, metrics, 1)
+
+			metric := metrics["request_duration_seconds"]
+			require.Equal(t, pmetric.MetricDataTypeHistogram, metric.DataType())
+			assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.Histogram().AggregationTemporality())
+			require.Equal(t, 2, metric.Histogram().DataPoints().Len())
+
+			dp := metric.Histogram().DataPoints().At(0)
+			assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+			assert.Equal(t, pcommon.Timestamp(1000*1e6), dp.Timestamp())
+			assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{2, 3, 1}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(6), dp.Count())
+			assert.Equal(t, 3.5, dp.Sum())
+
+			dp = metric.Histogram().DataPoints().At(1)
+			assert.Equal(t, map[string]interface{}{"path": "/health"}, dp.Attributes().AsRaw())
+			assert.Empty(t, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{1}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(1), dp.Count())
+			assert.False(t, dp.HasSum())
+		})
+	}
+}
+
+func TestToMetricsSummary(t *testing.T) {
+	md, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(0.2, 1000, "__name__", "rpc_duration_seconds", "quantile", "0.99"),
+			series(0.05, 1000, "__name__", "rpc_duration_seconds", "quantile", "0.5"),
+			series(12.5, 1000, "__name__", "rpc_duration_seconds_sum"),
+			series(100, 1000, "__name__", "rpc_duration_seconds_count"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+
+	metric := metrics["rpc_duration_seconds"]
+	require.Equal(t, pmetric.MetricDataTypeSummary, metric.DataType())
+	require.Equal(t, 1, metric.Summary().DataPoints().Len())
+	dp := metric.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(100), dp.Count())
+	assert.Equal(t, 12.5, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.05, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(1).Value())
+}
+
+func TestToMetricsUnrelatedSuffixes(t *testing.T) {
+	md, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(3, 1000, "__name__", "node_cpu_count"),
+			series(10, 1000, "__name__", "queue_bucket", "name", "default"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, pmetric.MetricDataTypeGauge, metrics["node_cpu_count"].DataType())
+	assert.Equal(t, pmetric.MetricDataTypeGauge, metrics["queue_bucket"].DataType())
+}
+
+func TestToMetricsStaleMarkers(t *testing.T) {
+	stale := math.Float64frombits(value.StaleNaN)
+	md, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(stale, 1000, "__name__", "up"),
+			series(stale, 1000, "__name__", "latency_bucket", "le", "+Inf"),
+			series(stale, 1000, "__name__", "latency_count"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+
+	assert.True(t, metrics["up"].Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+	require.Equal(t, 1, metrics["latency"].Histogram().DataPoints().Len())
+	assert.True(t, metrics["latency"].Histogram().DataPoints().At(0).Flags().NoRecordedValue())
+}
+
+func TestToMetricsErrors(t *testing.T) {
+	_, err := toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{series(1, 1000, "job", "node")},
+	})
+	assert.ErrorIs(t, err, errMissingName)
+
+	_, err = toMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{series(1, 1000, "__name__", "latency_bucket", "le", "fast")},
+	})
+	assert.ErrorContains(t, err, "invalid le label")
+}
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.uber.org/multierr"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -52,7 +53,7 @@ func (c *Config) validate() error {
 		}
 
 		switch eachMap.StatsdType {
-		case protocol.TimingTypeName, protocol.TimingAltTypeName, protocol.HistogramTypeName:
+		case protocol.TimingTypeName, protocol.TimingAltTypeName, protocol.HistogramTypeName, protocol.DistributionTypeName:
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("statsd_type is not a supported mapping: %s", eachMap.StatsdType))
 		}
@@ -63,10 +64,17 @@ func (c *Config) validate() error {
 		}
 
 		switch eachMap.ObserverType {
-		case protocol.GaugeObserver, protocol.SummaryObserver:
+		case protocol.GaugeObserver, protocol.SummaryObserver, protocol.HistogramObserver:
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("observer_type is not supported: %s", eachMap.ObserverType))
 		}
+
+		if eachMap.ObserverType != protocol.HistogramObserver && eachMap.Histogram.MaxSize != 0 {
+			errs = multierr.Append(errs, fmt.Errorf("histogram configuration requires observer_type: histogram"))
+		}
+		if eachMap.Histogram.MaxSize != 0 && eachMap.Histogram.MaxSize < expohisto.MinSize {
+			errs = multierr.Append(errs, fmt.Errorf("histogram max_size must be at least %d", expohisto.MinSize))
+		}
 	}
 
 	if TimerHistogramMappingMissingObjectName {
@@ -54,7 +54,7 @@ func TestLoadConfig(t *testing.T) {
 			Transport: "custom_transport",
 		},
 		AggregationInterval:   70 * time.Second,
-		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
+		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 100}}},
 	}, r1)
 }
 
@@ -70,6 +70,8 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		histogramWithoutObserverErr    = "histogram configuration requires observer_type: histogram"
+		histogramMaxSizeErr            = "histogram max_size must be at least 2"
 	)
 
 	tests := []test{
@@ -123,6 +125,26 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "HistogramConfigWithoutHistogramObserver",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "summary", Histogram: protocol.HistogramConfig{MaxSize: 100}},
+				},
+			},
+			expectedErr: histogramWithoutObserverErr,
+		},
+		{
+			name: "HistogramMaxSizeTooSmall",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 1}},
+				},
+			},
+			expectedErr: histogramMaxSizeErr,
+		},
 	}
 
 	for _, test := range tests {
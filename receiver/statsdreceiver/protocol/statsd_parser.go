@@ -23,6 +23,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/expohisto"
 )
 
 var (
@@ -33,7 +35,7 @@ var (
 type (
 	MetricType   string // From the statsd line e.g., "c", "g", "h"
 	TypeName     string // How humans describe the MetricTypes ("counter", "gauge")
-	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary")
+	ObserverType string // How the server will aggregate histograms, timings and distributions ("gauge", "summary", "histogram")
 )
 
 const (
@@ -43,16 +45,23 @@ const (
 	GaugeType     MetricType = "g"
 	HistogramType MetricType = "h"
 	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
-
-	GaugeObserver   ObserverType = "gauge"
-	SummaryObserver ObserverType = "summary"
-	DisableObserver ObserverType = "disabled"
+	SetType       MetricType = "s"
+	// DistributionType is the DogStatsD distribution type, which is observed
+	// the same way as histograms and timings.
+	DistributionType MetricType = "d"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	SetTypeName          TypeName = "set"
+	DistributionTypeName TypeName = "distribution"
+
+	GaugeObserver     ObserverType = "gauge"
+	SummaryObserver   ObserverType = "summary"
+	HistogramObserver ObserverType = "histogram"
+	DisableObserver   ObserverType = "disabled"
 
 	DefaultObserverType = DisableObserver
 )
@@ -60,18 +69,39 @@ const (
 type TimerHistogramMapping struct {
 	StatsdType   TypeName     `mapstructure:"statsd_type"`
 	ObserverType ObserverType `mapstructure:"observer_type"`
+	// Histogram configures the exponential histograms of the histogram observer.
+	Histogram HistogramConfig `mapstructure:"histogram"`
+}
+
+// HistogramConfig configures the exponential histograms of the histogram observer.
+type HistogramConfig struct {
+	// MaxSize is the maximum number of buckets of each histogram, for the
+	// positive and for the negative values. The scale is reduced whenever
+	// the values do not fit anymore. The default is expohisto.DefaultMaxSize.
+	MaxSize int32 `mapstructure:"max_size"`
+}
+
+// ObserverCategory is how a statsd type is observed.
+type ObserverCategory struct {
+	method          ObserverType
+	histogramConfig HistogramConfig
 }
 
+var defaultObserverCategory = ObserverCategory{method: DefaultObserverType}
+
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
+	sets                   map[statsDMetricDescription]map[string]struct{}
 	summaries              map[statsDMetricDescription]summaryMetric
+	histograms             map[statsDMetricDescription]*expohisto.Histogram
 	timersAndDistributions []pmetric.ScopeMetrics
 	enableMetricType       bool
 	isMonotonicCounter     bool
-	observeTimer           ObserverType
-	observeHistogram       ObserverType
+	observeTimer           ObserverCategory
+	observeHistogram       ObserverCategory
+	observeDistribution    ObserverCategory
 	lastIntervalTime       time.Time
 }
 
@@ -88,9 +118,11 @@ type summaryMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
-	addition    bool
-	unit        string
-	sampleRate  float64
+	// setValue is the raw value of set metrics, which are not numeric.
+	setValue   string
+	addition   bool
+	unit       string
+	sampleRate float64
 }
 
 type statsDMetricDescription struct {
@@ -109,6 +141,10 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case SetType:
+		return SetTypeName
+	case DistributionType:
+		return DistributionTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -117,19 +153,31 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.lastIntervalTime = timeNowFunc()
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]*expohisto.Histogram)
 
-	p.observeHistogram = DefaultObserverType
-	p.observeTimer = DefaultObserverType
+	p.observeHistogram = defaultObserverCategory
+	p.observeTimer = defaultObserverCategory
+	p.observeDistribution = defaultObserverCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).vaidate()
 	for _, eachMap := range sendTimerHistogram {
+		category := ObserverCategory{
+			method:          eachMap.ObserverType,
+			histogramConfig: eachMap.Histogram,
+		}
+		if category.histogramConfig.MaxSize == 0 {
+			category.histogramConfig.MaxSize = expohisto.DefaultMaxSize
+		}
 		switch eachMap.StatsdType {
 		case HistogramTypeName:
-			p.observeHistogram = eachMap.ObserverType
+			p.observeHistogram = category
 		case TimingTypeName, TimingAltTypeName:
-			p.observeTimer = eachMap.ObserverType
+			p.observeTimer = category
+		case DistributionTypeName:
+			p.observeDistribution = category
 		}
 	}
 	return nil
@@ -152,6 +200,10 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 		metric.CopyTo(rm.ScopeMetrics().AppendEmpty())
 	}
 
+	for desc, values := range p.sets {
+		buildSetMetric(desc, values, timeNowFunc(), rm.ScopeMetrics().AppendEmpty())
+	}
+
 	for desc, summaryMetric := range p.summaries {
 		buildSummaryMetric(
 			desc,
@@ -163,23 +215,37 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 		)
 	}
 
+	for desc, histogram := range p.histograms {
+		buildHistogramMetric(
+			desc,
+			histogram,
+			p.lastIntervalTime,
+			timeNowFunc(),
+			rm.ScopeMetrics().AppendEmpty(),
+		)
+	}
+
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 	p.timersAndDistributions = nil
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]*expohisto.Histogram)
 	return metrics
 }
 
 var timeNowFunc = time.Now
 
-func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
+func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	switch t {
 	case HistogramType:
 		return p.observeHistogram
 	case TimingType:
 		return p.observeTimer
+	case DistributionType:
+		return p.observeDistribution
 	}
-	return DisableObserver
+	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
@@ -213,8 +279,17 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntVal(point.IntVal() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
-		switch p.observerTypeFor(parsedMetric.description.metricType) {
+	case SetType:
+		values, ok := p.sets[parsedMetric.description]
+		if !ok {
+			values = make(map[string]struct{})
+			p.sets[parsedMetric.description] = values
+		}
+		values[parsedMetric.setValue] = struct{}{}
+
+	case TimingType, HistogramType, DistributionType:
+		category := p.observerCategoryFor(parsedMetric.description.metricType)
+		switch category.method {
 		case GaugeObserver:
 			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
 		case SummaryObserver:
@@ -230,6 +305,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 					weights: append(existing.weights, raw.count),
 				}
 			}
+		case HistogramObserver:
+			raw := parsedMetric.summaryValue()
+			histogram, ok := p.histograms[parsedMetric.description]
+			if !ok {
+				histogram = expohisto.New(category.histogramConfig.MaxSize, expohisto.MaxScale)
+				p.histograms[parsedMetric.description] = histogram
+			}
+			// Note: count is rounded here, see note in counterValue().
+			histogram.UpdateByIncr(raw.value, uint64(raw.count))
 		case DisableObserver:
 			// No action.
 		}
@@ -265,7 +349,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, SetType, DistributionType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -304,10 +388,16 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	if result.description.metricType == SetType {
+		// Set values are only counted, they can be any string (e.g. user IDs).
+		result.setValue = valueStr
+		result.addition = false
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
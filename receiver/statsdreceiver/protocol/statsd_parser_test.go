@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -228,6 +229,31 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"h", 0, nil, nil),
 		},
+		{
+			name:  "int distribution",
+			input: "test.metric:42|d",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"d", 0, nil, nil),
+		},
+		{
+			name:  "invalid distribution metric value",
+			input: "test.metric:42.abc|d",
+			err:   errors.New("parse metric value string: 42.abc"),
+		},
+		{
+			name:  "string set",
+			input: "test.metric:-user42|s",
+			wantMetric: statsDMetric{
+				description: statsDMetricDescription{
+					name:       "test.metric",
+					metricType: "s",
+				},
+				setValue: "-user42",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -417,6 +443,17 @@ func Test_ParseMessageToMetricWithMetricType(t *testing.T) {
 				[]string{"metric_type"},
 				[]string{"histogram"}),
 		},
+		{
+			name:  "int distribution",
+			input: "test.metric:42|d",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"d", 0,
+				[]string{"metric_type"},
+				[]string{"distribution"}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -887,6 +924,107 @@ func TestStatsDParser_AggregateTimerWithSummary(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateSet(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	for _, line := range []string{
+		"statsdTestMetric1:user1|s|#mykey:myvalue",
+		"statsdTestMetric1:user2|s|#mykey:myvalue",
+		"statsdTestMetric1:user1|s|#mykey:myvalue",
+		"statsdTestMetric1:user3|s|#mykey:othervalue",
+		"statsdTestMetric2:42|s",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+	assert.Equal(t, map[statsDMetricDescription]map[string]struct{}{
+		testDescription("statsdTestMetric1", "s", []string{"mykey"}, []string{"myvalue"}):    {"user1": {}, "user2": {}},
+		testDescription("statsdTestMetric1", "s", []string{"mykey"}, []string{"othervalue"}): {"user3": {}},
+		{name: "statsdTestMetric2", metricType: "s"}:                                         {"42": {}},
+	}, p.sets)
+
+	counts := map[string]int64{}
+	ilm := p.GetMetrics().ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < ilm.Len(); i++ {
+		m := ilm.At(i).Metrics().At(0)
+		assert.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+		dp := m.Gauge().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+		key := m.Name()
+		if v, ok := dp.Attributes().Get("mykey"); ok {
+			key += "/" + v.StringVal()
+		}
+		counts[key] = dp.IntVal()
+	}
+	assert.Equal(t, map[string]int64{
+		"statsdTestMetric1/myvalue":    2,
+		"statsdTestMetric1/othervalue": 1,
+		"statsdTestMetric2":            1,
+	}, counts)
+	assert.Empty(t, p.sets)
+}
+
+func TestStatsDParser_AggregateWithHistogram(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "timer", ObserverType: "histogram"},
+		{StatsdType: "histogram", ObserverType: "histogram", Histogram: HistogramConfig{MaxSize: 2}},
+	}))
+	p.lastIntervalTime = time.Unix(611, 0)
+	for _, line := range []string{
+		"statsdTestMetric1:1|ms|#mykey:myvalue",
+		"statsdTestMetric1:2|ms|#mykey:myvalue",
+		"statsdTestMetric1:0|ms|#mykey:myvalue",
+		"statsdTestMetric1:4|ms|@0.5|#mykey:myvalue",
+		"statsdTestMetric2:1|h",
+		"statsdTestMetric2:1000|h",
+		"statsdTestMetric2:1000000|h",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+	assert.Len(t, p.histograms, 2)
+
+	timer := p.histograms[testDescription("statsdTestMetric1", "ms", []string{"mykey"}, []string{"myvalue"})]
+	assert.Equal(t, uint64(5), timer.Count())
+	histogram := p.histograms[statsDMetricDescription{name: "statsdTestMetric2", metricType: "h"}]
+	assert.Equal(t, uint64(3), histogram.Count())
+	assert.Less(t, histogram.Scale(), timer.Scale())
+
+	metrics := p.GetMetrics()
+	ilm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	assert.Equal(t, 2, ilm.Len())
+	for i := 0; i < ilm.Len(); i++ {
+		m := ilm.At(i).Metrics().At(0)
+		assert.Equal(t, pmetric.MetricDataTypeExponentialHistogram, m.DataType())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.ExponentialHistogram().AggregationTemporality())
+		dp := m.ExponentialHistogram().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(611, 0)), dp.StartTimestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+		switch m.Name() {
+		case "statsdTestMetric1":
+			assert.Equal(t, uint64(5), dp.Count())
+			assert.Equal(t, float64(11), dp.Sum())
+			assert.Equal(t, uint64(1), dp.ZeroCount())
+			v, ok := dp.Attributes().Get("mykey")
+			assert.True(t, ok)
+			assert.Equal(t, "myvalue", v.StringVal())
+		case "statsdTestMetric2":
+			assert.Equal(t, uint64(3), dp.Count())
+			assert.LessOrEqual(t, dp.Positive().BucketCounts().Len(), 2)
+		default:
+			t.Errorf("unexpected metric %s", m.Name())
+		}
+	}
+	assert.Empty(t, p.histograms)
+}
+
 func TestStatsDParser_Initialize(t *testing.T) {
 	p := &StatsDParser{}
 	assert.NoError(t, p.Initialize(true, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
@@ -896,8 +1034,8 @@ func TestStatsDParser_Initialize(t *testing.T) {
 		attrs:      *attribute.EmptySet()}
 	p.gauges[teststatsdDMetricdescription] = pmetric.ScopeMetrics{}
 	assert.Equal(t, 1, len(p.gauges))
-	assert.Equal(t, GaugeObserver, p.observeTimer)
-	assert.Equal(t, GaugeObserver, p.observeHistogram)
+	assert.Equal(t, GaugeObserver, p.observeTimer.method)
+	assert.Equal(t, GaugeObserver, p.observeHistogram.method)
 }
 
 func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
@@ -962,6 +1100,24 @@ func TestStatsDParser_Mappings(t *testing.T) {
 				"Gauge":   "H",
 			},
 		},
+		{
+			name: "distribution-to-summary",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "summary"},
+			},
+			expect: map[string]string{
+				"Summary": "D",
+			},
+		},
+		{
+			name: "timer-to-histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "timer", ObserverType: "histogram"},
+			},
+			expect: map[string]string{
+				"ExponentialHistogram": "T",
+			},
+		},
 		{
 			name: "timer-to-gauge",
 			mapping: []TimerHistogramMapping{
@@ -979,6 +1135,7 @@ func TestStatsDParser_Mappings(t *testing.T) {
 
 			assert.NoError(t, p.Aggregate("H:10|h"))
 			assert.NoError(t, p.Aggregate("T:10|ms"))
+			assert.NoError(t, p.Aggregate("D:10|d"))
 
 			typeNames := map[string]string{}
 
@@ -16,6 +16,7 @@ package transport
 
 import (
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -114,3 +115,92 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_StreamServer_ListenAndServe(t *testing.T) {
+	tests := []struct {
+		name          string
+		addrFn        func(t testing.TB) string
+		buildServerFn func(addr string) (Server, error)
+		buildClientFn func(addr string) (*client.StatsD, error)
+	}{
+		{
+			name:          "tcp",
+			addrFn:        testutil.GetAvailableLocalAddress,
+			buildServerFn: NewTCPServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, portStr, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+				port, err := strconv.Atoi(portStr)
+				if err != nil {
+					return nil, err
+				}
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name: "unix",
+			addrFn: func(t testing.TB) string {
+				return filepath.Join(t.TempDir(), "statsd.sock")
+			},
+			buildServerFn: NewUnixServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unix", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "unix" && runtime.GOOS == "windows" {
+				t.Skip("Unix domain sockets are not supported on windows")
+			}
+			addr := tt.addrFn(t)
+
+			srv, err := tt.buildServerFn(addr)
+			require.NoError(t, err)
+			require.NotNil(t, srv)
+
+			mc := new(consumertest.MetricsSink)
+			p := &protocol.StatsDParser{}
+			mr := NewMockReporter(1)
+			var transferChan = make(chan string, 10)
+
+			wgListenAndServe := sync.WaitGroup{}
+			wgListenAndServe.Add(1)
+			go func() {
+				defer wgListenAndServe.Done()
+				assert.Error(t, srv.ListenAndServe(p, mc, mr, transferChan))
+			}()
+
+			gc, err := tt.buildClientFn(addr)
+			require.NoError(t, err)
+			require.NotNil(t, gc)
+			// Two metrics in a single write, and one split across writes.
+			_, err = gc.Conn.Write([]byte("test.metric:42|c\ntest.metric:1|g\ntest.me"))
+			assert.NoError(t, err)
+			_, err = gc.Conn.Write([]byte("tric:3|s\n"))
+			assert.NoError(t, err)
+			err = gc.Disconnect()
+			assert.NoError(t, err)
+
+			assert.Eventually(t, func() bool {
+				return len(transferChan) == 3
+			}, 10*time.Second, 100*time.Millisecond)
+
+			// Close the server connection, this will cause ListenAndServer to error out and the deferred wgListenAndServe.Done will fire
+			err = srv.Close()
+			assert.NoError(t, err)
+
+			wgListenAndServe.Wait()
+			require.Equal(t, 3, len(transferChan))
+			assert.Equal(t, "test.metric:42|c", <-transferChan)
+			assert.Equal(t, "test.metric:1|g", <-transferChan)
+			assert.Equal(t, "test.metric:3|s", <-transferChan)
+		})
+	}
+}